@@ -25,7 +25,9 @@ func (b *boolValue) Set(s string) error {
 
 func (b *boolValue) Get() any { return bool(*b) }
 
+func (b *boolValue) Type() string { return "bool" }
+
 // Bool sets environment variable and returns the pointer of value.
-func Bool(name string, value bool) *bool {
-	return environmentVariableSetInstance.Bool(name, value)
+func Bool(name string, value bool, opts ...Option) *bool {
+	return environmentVariableSetInstance.Bool(name, value, opts...)
 }
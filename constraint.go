@@ -0,0 +1,159 @@
+package getenv
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var _ error = (*ConstraintError)(nil) // compile time proof.
+
+// ConstraintError represents a violated constraint, Names has every
+// variable involved.
+type ConstraintError struct {
+	Names  []string
+	detail string
+}
+
+func (e *ConstraintError) Error() string { return fmt.Sprintf("[%v] %s", ErrConstraint, e.detail) }
+
+// Unwrap returns ErrConstraint.
+func (e *ConstraintError) Unwrap() error { return ErrConstraint }
+
+type constraintKind int
+
+const (
+	constraintRequires constraintKind = iota
+	constraintMutuallyExclusive
+	constraintExactlyOneOf
+)
+
+type constraint struct {
+	names []string
+	kind  constraintKind
+}
+
+// Requires declares that if name is set, every one of others must be set
+// too. Names are resolved with the prefix like Var. Unlike IsSet, an empty
+// value counts as set only if the variable allows empty, this applies to
+// every constraint.
+func (e *EnvironmentVariableSet) Requires(name string, others ...string) {
+	e.addConstraint(constraintRequires, append([]string{name}, others...))
+}
+
+// MutuallyExclusive declares that at most one of names can be set.
+func (e *EnvironmentVariableSet) MutuallyExclusive(names ...string) {
+	e.addConstraint(constraintMutuallyExclusive, names)
+}
+
+// ExactlyOneOf declares that exactly one of names must be set.
+func (e *EnvironmentVariableSet) ExactlyOneOf(names ...string) {
+	e.addConstraint(constraintExactlyOneOf, names)
+}
+
+func (e *EnvironmentVariableSet) addConstraint(kind constraintKind, names []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	resolved := make([]string, len(names))
+	for i, name := range names {
+		resolved[i] = e.resolveName(name)
+	}
+
+	e.constraints = append(e.constraints, constraint{kind: kind, names: resolved})
+}
+
+// checkConstraints checks the constraints in declaration order, a variable
+// is set if the source has a non empty value for it, or an empty one and the
+// variable allows empty.
+func (e *EnvironmentVariableSet) checkConstraints(source Source) ParseErrors {
+	var errs ParseErrors
+
+	isSet := func(name string) bool {
+		val, _, found, err := e.lookup(source, name)
+
+		if err != nil || !found {
+			return false
+		}
+		if val != "" || e.allowEmpty {
+			return true
+		}
+
+		envVar, ok := e.variables[name]
+
+		return ok && envVar.allowEmpty
+	}
+
+	for _, c := range e.constraints {
+		var set []string
+
+		for _, name := range c.names {
+			if isSet(name) {
+				set = append(set, name)
+			}
+		}
+
+		switch c.kind {
+		case constraintRequires:
+			if !slices.Contains(set, c.names[0]) {
+				continue
+			}
+
+			var missing []string
+
+			for _, name := range c.names[1:] {
+				if !slices.Contains(set, name) {
+					missing = append(missing, name)
+				}
+			}
+			if len(missing) > 0 {
+				errs = append(errs, newConstraintError(c.names[0], c.names, "requires "+quoteNames(missing)))
+			}
+		case constraintMutuallyExclusive:
+			if len(set) > 1 {
+				errs = append(errs, newConstraintError(set[0], c.names, "conflicts with "+quoteNames(set[1:])))
+			}
+		case constraintExactlyOneOf:
+			if len(set) != 1 {
+				detail := fmt.Sprintf("exactly one of %s must be set, got %d", quoteNames(c.names), len(set))
+				errs = append(errs, newConstraintError(c.names[0], c.names, detail))
+			}
+		}
+	}
+
+	return errs
+}
+
+func newConstraintError(name string, names []string, detail string) *ParseError {
+	return &ParseError{
+		Name: name,
+		Err:  &ConstraintError{Names: names, detail: detail},
+	}
+}
+
+func quoteNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// Requires declares that if name is set, others must be set too in the
+// global set.
+func Requires(name string, others ...string) {
+	environmentVariableSetInstance.Requires(name, others...)
+}
+
+// MutuallyExclusive declares that at most one of names can be set in the
+// global set.
+func MutuallyExclusive(names ...string) {
+	environmentVariableSetInstance.MutuallyExclusive(names...)
+}
+
+// ExactlyOneOf declares that exactly one of names must be set in the global
+// set.
+func ExactlyOneOf(names ...string) {
+	environmentVariableSetInstance.ExactlyOneOf(names...)
+}
@@ -0,0 +1,340 @@
+package getenv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var _ error = (*DotEnvError)(nil) // compile time proof.
+
+// DotEnvError represents a malformed line of a .env file.
+type DotEnvError struct {
+	Err  error
+	Line int
+}
+
+func (e *DotEnvError) Error() string { return fmt.Sprintf("line %d %v", e.Line, e.Err) }
+
+// Unwrap returns the underlying error.
+func (e *DotEnvError) Unwrap() error { return e.Err }
+
+// ParseDotEnv parses .env formatted content into a MapSource, which can be
+// used as the Source of an EnvironmentVariableSet. Supported syntax:
+//
+//	# comments and blank lines are ignored
+//	export PORT=8000              # "export" prefix and inline comments
+//	NAME='single $quoted'         # taken as is
+//	RAW=`backtick $quoted`        # taken as is
+//	GREETING="hello\n${NAME}"     # escape sequences and expansion
+//	CERT="-----BEGIN-----
+//	...
+//	-----END-----"                # quoted values may span lines
+//	URL=http://$HOST:${PORT}/     # expansion in unquoted values
+//
+// ${VAR} and $VAR references are expanded from the variables defined above
+// them, then from the process environment. Malformed lines are reported as
+// *DotEnvError wrapping ErrInvalid.
+func ParseDotEnv(r io.Reader) (MapSource, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	p := &dotEnvParser{
+		src:      string(src),
+		line:     1,
+		values:   make(MapSource),
+		fallback: OSSource{},
+	}
+	if err = p.parse(); err != nil {
+		return nil, err
+	}
+
+	return p.values, nil
+}
+
+// ReadDotEnvFile reads and parses the .env file at path, see ParseDotEnv.
+func ReadDotEnvFile(path string) (MapSource, error) {
+	f, err := os.Open(path) //nolint:gosec // reading the given file is the purpose.
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	values, err := ParseDotEnv(f)
+	if err != nil {
+		return nil, fmt.Errorf("%q %w", path, err)
+	}
+
+	return values, nil
+}
+
+type dotEnvParser struct {
+	fallback Source
+	values   MapSource
+	src      string
+	pos      int
+	line     int
+}
+
+func (p *dotEnvParser) errorf(line int, format string, args ...any) error {
+	return &DotEnvError{
+		Line: line,
+		Err:  fmt.Errorf("[%w] %s", ErrInvalid, fmt.Sprintf(format, args...)),
+	}
+}
+
+func (p *dotEnvParser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *dotEnvParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.src[p.pos]
+}
+
+func (p *dotEnvParser) skipBlanks() {
+	for !p.eof() && isBlank(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *dotEnvParser) skipLine() {
+	for !p.eof() && p.src[p.pos] != '\n' {
+		p.pos++
+	}
+}
+
+func (p *dotEnvParser) parse() error {
+	for {
+		p.skipBlanks()
+		if p.eof() {
+			return nil
+		}
+
+		switch p.peek() {
+		case '\n':
+			p.pos++
+			p.line++
+
+			continue
+		case '#':
+			p.skipLine()
+
+			continue
+		}
+
+		if err := p.assignment(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *dotEnvParser) assignment() error {
+	line := p.line
+
+	if rest := p.src[p.pos:]; strings.HasPrefix(rest, "export") && len(rest) > 6 && isBlank(rest[6]) {
+		p.pos += 6
+		p.skipBlanks()
+	}
+
+	start := p.pos
+	for !p.eof() && p.peek() != '=' && p.peek() != '\n' && !isBlank(p.peek()) {
+		p.pos++
+	}
+
+	key := p.src[start:p.pos]
+	if !isValidDotEnvKey(key) {
+		return p.errorf(line, "invalid variable name %q", key)
+	}
+
+	p.skipBlanks()
+	if p.peek() != '=' {
+		return p.errorf(line, "missing '=' after %q", key)
+	}
+	p.pos++
+	p.skipBlanks()
+
+	val, err := p.value()
+	if err != nil {
+		return err
+	}
+	p.values[key] = val
+
+	return nil
+}
+
+func (p *dotEnvParser) value() (string, error) {
+	switch quote := p.peek(); quote {
+	case '\'', '`':
+		return p.quoted(quote, false)
+	case '"':
+		return p.quoted(quote, true)
+	}
+
+	return p.unquoted()
+}
+
+func (p *dotEnvParser) quoted(quote byte, interpret bool) (string, error) {
+	line := p.line
+	p.pos++
+
+	var b strings.Builder
+
+	for !p.eof() {
+		c := p.src[p.pos]
+
+		switch {
+		case c == quote:
+			p.pos++
+
+			return b.String(), p.endOfValue(line)
+		case c == '\n':
+			p.line++
+		case interpret && c == '\\' && p.pos+1 < len(p.src):
+			if p.src[p.pos+1] == '\n' {
+				p.line++
+			}
+			b.WriteString(unescapeDotEnv(p.src[p.pos+1]))
+			p.pos += 2
+
+			continue
+		case interpret && c == '$':
+			val, err := p.reference()
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(val)
+
+			continue
+		}
+
+		b.WriteByte(c)
+		p.pos++
+	}
+
+	return "", p.errorf(line, "unterminated %c quote", quote)
+}
+
+func (p *dotEnvParser) unquoted() (string, error) {
+	var b strings.Builder
+
+	prev := byte(' ')
+	for !p.eof() && p.peek() != '\n' {
+		c := p.src[p.pos]
+		if c == '#' && isBlank(prev) {
+			p.skipLine()
+
+			break
+		}
+
+		prev = c
+		if c == '$' {
+			val, err := p.reference()
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(val)
+
+			continue
+		}
+
+		b.WriteByte(c)
+		p.pos++
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
+
+// endOfValue checks that only blanks or a comment follow a quoted value.
+func (p *dotEnvParser) endOfValue(line int) error {
+	p.skipBlanks()
+
+	switch p.peek() {
+	case 0, '\n':
+		return nil
+	case '#':
+		p.skipLine()
+
+		return nil
+	}
+
+	return p.errorf(line, "unexpected %q after quoted value", p.peek())
+}
+
+// reference expands the $VAR or ${VAR} reference at the current position, a
+// '$' not followed by a variable name is kept as is.
+func (p *dotEnvParser) reference() (string, error) {
+	p.pos++
+
+	var name string
+
+	switch {
+	case p.peek() == '{':
+		end := strings.IndexAny(p.src[p.pos:], "}\n")
+		if end == -1 || p.src[p.pos+end] != '}' {
+			return "", p.errorf(p.line, "unterminated variable reference")
+		}
+
+		name = p.src[p.pos+1 : p.pos+end]
+		if !isValidDotEnvKey(name) {
+			return "", p.errorf(p.line, "invalid variable reference %q", name)
+		}
+		p.pos += end + 1
+	case isDotEnvKeyStart(p.peek()):
+		start := p.pos
+		for !p.eof() && (isDotEnvKeyStart(p.peek()) || isDigit(p.peek())) {
+			p.pos++
+		}
+		name = p.src[start:p.pos]
+	default:
+		return "$", nil
+	}
+
+	if val, ok := p.values[name]; ok {
+		return val, nil
+	}
+	val, _ := p.fallback.Lookup(name)
+
+	return val, nil
+}
+
+func unescapeDotEnv(c byte) string {
+	switch c {
+	case 'n':
+		return "\n"
+	case 'r':
+		return "\r"
+	case 't':
+		return "\t"
+	case '\\', '"', '$':
+		return string(c)
+	}
+
+	return "\\" + string(c)
+}
+
+func isValidDotEnvKey(key string) bool {
+	if key == "" || !isDotEnvKeyStart(key[0]) {
+		return false
+	}
+
+	for i := 1; i < len(key); i++ {
+		if c := key[i]; !isDotEnvKeyStart(c) && !isDigit(c) && c != '.' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDotEnvKeyStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isBlank(c byte) bool { return c == ' ' || c == '\t' || c == '\r' }
@@ -0,0 +1,170 @@
+package getenv_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vigo/getenv"
+)
+
+func ExampleParseDotEnv() {
+	source, err := getenv.ParseDotEnv(strings.NewReader("PORT=9000\nLISTEN=:${PORT}\n"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	set := getenv.NewEnvironmentVariableSet("app", getenv.ContinueOnError)
+	set.SetSource(source)
+	listen := set.TCPAddr("LISTEN", ":4000")
+	if err = set.Parse(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(*listen)
+	// Output: :9000
+}
+
+func TestParseDotEnv(t *testing.T) {
+	os.Setenv("TEST_DOTENV_OS", "from-os")
+
+	defer func() {
+		os.Unsetenv("TEST_DOTENV_OS")
+	}()
+
+	content := strings.Join([]string{
+		"# comment",
+		"",
+		"PLAIN=value",
+		"  SPACED = spaced value  ",
+		"export EXPORTED=exported",
+		"INLINE=value # comment",
+		"HASH=value#hash",
+		"EMPTY=",
+		"SINGLE='single $PLAIN \\n'",
+		"BACKTICK=`backtick \"$PLAIN\"`",
+		`DOUBLE="line1\nline2\t\"quoted\" \$PLAIN"`,
+		`EXPANDED="${PLAIN}-$PLAIN-$TEST_DOTENV_OS-${MISSING}"`,
+		"UNQUOTED_EXPANDED=$PLAIN/${EXPORTED}/$",
+		`MULTILINE="first`,
+		`second"   # comment`,
+		"MULTILINE_SINGLE='a",
+		"b'",
+		"WINDOWS=crlf\r",
+		"AFTER=after",
+	}, "\n")
+
+	values, err := getenv.ParseDotEnv(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"PLAIN":             "value",
+		"SPACED":            "spaced value",
+		"EXPORTED":          "exported",
+		"INLINE":            "value",
+		"HASH":              "value#hash",
+		"EMPTY":             "",
+		"SINGLE":            "single $PLAIN \\n",
+		"BACKTICK":          "backtick \"$PLAIN\"",
+		"DOUBLE":            "line1\nline2\t\"quoted\" $PLAIN",
+		"EXPANDED":          "value-value-from-os-",
+		"UNQUOTED_EXPANDED": "value/exported/$",
+		"MULTILINE":         "first\nsecond",
+		"MULTILINE_SINGLE":  "a\nb",
+		"WINDOWS":           "crlf",
+		"AFTER":             "after",
+	}
+
+	if len(values) != len(want) {
+		t.Errorf("len, want [%d], got: [%d]", len(want), len(values))
+	}
+	for key, wantValue := range want {
+		got, ok := values.Lookup(key)
+		if !ok || got != wantValue {
+			t.Errorf("%s, want [%q], got: [%q]", key, wantValue, got)
+		}
+	}
+}
+
+func TestParseDotEnvErrors(t *testing.T) {
+	tcs := []struct {
+		testName     string
+		content      string
+		expectedLine int
+	}{
+		{
+			testName:     "missing equal sign should have an error",
+			content:      "A=1\nINVALID\n",
+			expectedLine: 2,
+		},
+		{
+			testName:     "invalid name should have an error",
+			content:      "A=1\n\n1INVALID=1\n",
+			expectedLine: 3,
+		},
+		{
+			testName:     "unterminated quote should have an error",
+			content:      "A=1\nB=\"open\nC=2\n",
+			expectedLine: 2,
+		},
+		{
+			testName:     "text after quoted value should have an error",
+			content:      "A='quoted' text\n",
+			expectedLine: 1,
+		},
+		{
+			testName:     "unterminated reference should have an error",
+			content:      "A=1\nB=\"x\"\nC=${A\n",
+			expectedLine: 3,
+		},
+		{
+			testName:     "escaped new line should be counted",
+			content:      "A=\"x\\\ny\"\nB=ok\n1BAD=x\n",
+			expectedLine: 4,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.testName, func(t *testing.T) {
+			_, err := getenv.ParseDotEnv(strings.NewReader(tc.content))
+
+			if !errors.Is(err, getenv.ErrInvalid) {
+				t.Errorf("want [%v], got: [%v]", getenv.ErrInvalid, err)
+			}
+
+			var dotEnvErr *getenv.DotEnvError
+			if !errors.As(err, &dotEnvErr) {
+				t.Fatalf("want *getenv.DotEnvError, got: [%T]", err)
+			}
+			if dotEnvErr.Line != tc.expectedLine {
+				t.Errorf("line, want [%d], got: [%d]", tc.expectedLine, dotEnvErr.Line)
+			}
+		})
+	}
+}
+
+func TestReadDotEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("PORT=9000\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := getenv.ReadDotEnvFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if values["PORT"] != "9000" {
+		t.Errorf("want [9000], got: [%s]", values["PORT"])
+	}
+
+	if _, err = getenv.ReadDotEnvFile(path + ".missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("want [%v], got: [%v]", os.ErrNotExist, err)
+	}
+}
@@ -25,7 +25,9 @@ func (d *durationValue) Set(s string) error {
 
 func (d *durationValue) Get() any { return time.Duration(*d) }
 
+func (d *durationValue) Type() string { return "duration" }
+
 // Duration sets environment variable and returns the pointer of value.
-func Duration(name string, value time.Duration) *time.Duration {
-	return environmentVariableSetInstance.Duration(name, value)
+func Duration(name string, value time.Duration, opts ...Option) *time.Duration {
+	return environmentVariableSetInstance.Duration(name, value, opts...)
 }
@@ -0,0 +1,47 @@
+package getenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// compile time proofs.
+var (
+	_ error = (*ParseError)(nil)
+	_ error = ParseErrors(nil)
+)
+
+// ParseError represents a failure of a single environment variable.
+type ParseError struct {
+	Err  error
+	Name string
+}
+
+func (e *ParseError) Error() string { return fmt.Sprintf("%q %v", e.Name, e.Err) }
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// ParseErrors collects every failure found during Parse, in registration
+// order.
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the collected errors, errors.Is and errors.As check each of
+// them.
+func (e ParseErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
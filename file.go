@@ -0,0 +1,100 @@
+package getenv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DefaultMaxFileSize is the default size limit of files read through the
+// file suffix, see EnvironmentVariableSet.SetFileSuffix.
+const DefaultMaxFileSize int64 = 64 << 10
+
+// FileSuffix returns the suffix of file indirection variables, empty if
+// disabled.
+func (e *EnvironmentVariableSet) FileSuffix() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.fileSuffix
+}
+
+// SetFileSuffix enables file indirection for every variable of the set. If
+// NAME is not set and NAME+suffix (e.g. DB_PASSWORD_FILE) is, the value is
+// read from the file it points, which is how Docker and Kubernetes secrets
+// are mounted. Empty suffix disables it.
+func (e *EnvironmentVariableSet) SetFileSuffix(suffix string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.fileSuffix = suffix
+}
+
+// SetMaxFileSize sets the size limit of files read through the file suffix,
+// zero or less means DefaultMaxFileSize.
+func (e *EnvironmentVariableSet) SetMaxFileSize(n int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.maxFileSize = n
+}
+
+// lookup returns the value of name and its origin from source, falling back
+// to the file indirection variable.
+func (e *EnvironmentVariableSet) lookup(source Source, name string) (string, Origin, bool, error) {
+	if val, origin, ok := lookupOrigin(source, name); ok {
+		return val, origin, true, nil
+	}
+
+	if e.fileSuffix == "" {
+		return "", "", false, nil
+	}
+
+	fileName := name + e.fileSuffix
+
+	path, ok := source.Lookup(fileName)
+	if !ok {
+		return "", "", false, nil
+	}
+
+	maxSize := e.maxFileSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxFileSize
+	}
+
+	val, err := readValueFile(path, maxSize)
+	if err != nil {
+		return "", "", false, fmt.Errorf("[%w] %s=%q %w", ErrInvalid, fileName, path, err)
+	}
+
+	return val, OriginFile, true, nil
+}
+
+// readValueFile reads the file at path up to maxSize bytes, trailing newline
+// is trimmed.
+func readValueFile(path string, maxSize int64) (string, error) {
+	f, err := os.Open(path) //nolint:gosec // reading the given file is the purpose.
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	if int64(len(content)) > maxSize {
+		return "", fmt.Errorf("file exceeds %d bytes", maxSize)
+	}
+
+	val := strings.TrimSuffix(string(content), "\n")
+
+	return strings.TrimSuffix(val, "\r"), nil
+}
+
+// SetFileSuffix enables file indirection for the global set.
+func SetFileSuffix(suffix string) {
+	environmentVariableSetInstance.SetFileSuffix(suffix)
+}
@@ -25,7 +25,9 @@ func (f *float64Value) Set(s string) error {
 
 func (f *float64Value) Get() any { return float64(*f) }
 
+func (f *float64Value) Type() string { return "float64" }
+
 // Float64 sets environment variable and returns the pointer of value.
-func Float64(name string, value float64) *float64 {
-	return environmentVariableSetInstance.Float64(name, value)
+func Float64(name string, value float64, opts ...Option) *float64 {
+	return environmentVariableSetInstance.Float64(name, value, opts...)
 }
@@ -0,0 +1,151 @@
+package getenv
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var valueRegistryInstance = newValueRegistry() //nolint:gochecknoglobals
+
+// valueFactory creates a Value for a pointer of the registered type.
+type valueFactory func(p any) Value
+
+type valueRegistry struct {
+	factories map[reflect.Type]valueFactory
+	mu        sync.RWMutex
+}
+
+func (r *valueRegistry) set(t reflect.Type, factory valueFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.factories[t] = factory
+}
+
+func (r *valueRegistry) get(t reflect.Type) (valueFactory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	factory, ok := r.factories[t]
+
+	return factory, ok
+}
+
+func newValueRegistry() *valueRegistry {
+	r := &valueRegistry{factories: make(map[reflect.Type]valueFactory)}
+
+	registerValue(r, func(p *bool) Value { return newBoolValue(*p, p) })
+	registerValue(r, func(p *int) Value { return newIntValue(*p, p) })
+	registerValue(r, func(p *int64) Value { return newInt64Value(*p, p) })
+	registerValue(r, func(p *float64) Value { return newFloat64Value(*p, p) })
+	registerValue(r, func(p *string) Value { return newStringValue(*p, p) })
+	registerValue(r, func(p *time.Duration) Value { return newDurationValue(*p, p) })
+	registerValue(r, func(p *[]string) Value { return newStringSliceValue(*p, p) })
+	registerValue(r, func(p *SecretString) Value { return newSecretValue(string(*p), p) })
+	registerValue(r, func(p *[]int) Value { return newIntSliceValue(*p, p) })
+	registerValue(r, func(p *[]int64) Value { return newInt64SliceValue(*p, p) })
+	registerValue(r, func(p *[]float64) Value { return newFloat64SliceValue(*p, p) })
+	registerValue(r, func(p *[]time.Duration) Value { return newDurationSliceValue(*p, p) })
+	registerValue(r, func(p *map[string]string) Value { return newStringMapValue(*p, p) })
+	registerValue(r, func(p *map[string]int) Value { return newIntMapValue(*p, p) })
+	registerValue(r, func(p *map[string]time.Duration) Value { return newDurationMapValue(*p, p) })
+	registerValue(r, func(p *url.URL) Value { return newURLValue(*p, p) })
+	registerValue(r, func(p *netip.Addr) Value { return newIPValue(*p, p, new(addrFamily)) })
+	registerValue(r, func(p *netip.Prefix) Value { return newIPPrefixValue(*p, p, new(addrFamily)) })
+	registerValue(r, func(p *netip.AddrPort) Value { return newAddrPortValue(*p, p, new(addrFamily)) })
+	registerValue(r, func(p *[]netip.Addr) Value { return newIPSliceValue(*p, p) })
+	registerValue(r, func(p *[]netip.Prefix) Value { return newIPPrefixSliceValue(*p, p) })
+	registerValue(r, func(p *[]netip.AddrPort) Value { return newAddrPortSliceValue(*p, p) })
+
+	return r
+}
+
+func registerValue[T any](r *valueRegistry, newValue func(p *T) Value) {
+	r.set(reflect.TypeFor[T](), func(p any) Value {
+		return newValue(p.(*T)) //nolint:errcheck // factories are called with *T only
+	})
+}
+
+type parserValue[T any] struct {
+	p     *T
+	parse func(s string) (T, error)
+}
+
+func (v *parserValue[T]) Set(s string) error {
+	val, err := v.parse(s)
+	if err != nil {
+		return fmt.Errorf("[%w] %w", ErrInvalid, err)
+	}
+	*v.p = val
+
+	return nil
+}
+
+func (v *parserValue[T]) Get() any { return *v.p }
+
+func (v *parserValue[T]) Type() string { return reflect.TypeFor[T]().String() }
+
+func (v *parserValue[T]) stage() Value {
+	staged := *v
+	staged.p = new(T)
+
+	return &staged
+}
+
+func (v *parserValue[T]) Restore(val any) error {
+	typed, ok := val.(T)
+	if !ok {
+		return fmt.Errorf("[%w] can not restore %T as %s", ErrInvalid, val, reflect.TypeFor[T]())
+	}
+	*v.p = typed
+
+	return nil
+}
+
+// RegisterParser registers parse as the parser of type T. Registered types
+// can be used with Get, GetVar, GetIn, GetVarIn and Load. Registering a type
+// again replaces its parser, built-in types included; a replaced built-in
+// type loses its type specific options, like IPv4Only for netip.Addr, which
+// then panic as not supported.
+func RegisterParser[T any](parse func(s string) (T, error)) {
+	registerValue(valueRegistryInstance, func(p *T) Value {
+		return &parserValue[T]{p: p, parse: parse}
+	})
+}
+
+// GetVarIn creates new variable of type T in the given set. It panics if no
+// parser is registered for T.
+func GetVarIn[T any](e *EnvironmentVariableSet, p *T, name string, value T, opts ...Option) {
+	factory, ok := valueRegistryInstance.get(reflect.TypeFor[T]())
+	if !ok {
+		panic(fmt.Sprintf("getenv: no parser registered for %s", reflect.TypeFor[T]()))
+	}
+
+	*p = value
+	e.Var(factory(p), name, opts...)
+}
+
+// GetIn creates new variable of type T in the given set and returns the
+// pointer of value. It panics if no parser is registered for T.
+func GetIn[T any](e *EnvironmentVariableSet, name string, value T, opts ...Option) *T {
+	p := new(T)
+	GetVarIn(e, p, name, value, opts...)
+
+	return p
+}
+
+// GetVar creates new variable of type T. It panics if no parser is
+// registered for T.
+func GetVar[T any](p *T, name string, value T, opts ...Option) {
+	GetVarIn(environmentVariableSetInstance, p, name, value, opts...)
+}
+
+// Get sets environment variable of type T and returns the pointer of value.
+// It panics if no parser is registered for T.
+func Get[T any](name string, value T, opts ...Option) *T {
+	return GetIn(environmentVariableSetInstance, name, value, opts...)
+}
@@ -3,18 +3,40 @@ package getenv
 import (
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/netip"
+	"net/url"
 	"os"
+	"slices"
+	"sync"
 	"time"
 )
 
 // sentinel errors.
 var (
-	ErrEnvironmentVariableNotFound = errors.New("not found")
-	ErrEnvironmentVariableIsEmpty  = errors.New("is empty")
-	ErrInvalid                     = errors.New("invalid")
+	ErrEnvironmentVariableNotFound  = errors.New("not found")
+	ErrEnvironmentVariableIsEmpty   = errors.New("is empty")
+	ErrInvalid                      = errors.New("invalid")
+	ErrEnvironmentVariableRedefined = errors.New("redefined")
+	ErrConstraint                   = errors.New("constraint violated")
 )
 
-var environmentVariableSetInstance = newEnvironmentVariableSet() //nolint:gochecknoglobals
+var environmentVariableSetInstance = NewEnvironmentVariableSet(os.Args[0], ContinueOnError) //nolint:gochecknoglobals
+
+// exitCodeParseError is the exit code used by ExitOnError, same as flag.
+const exitCodeParseError = 2
+
+// ErrorHandling defines how EnvironmentVariableSet.Parse behaves if the
+// parse fails.
+type ErrorHandling int
+
+// error handling modes.
+const (
+	ContinueOnError ErrorHandling = iota // return the error.
+	ExitOnError                          // print the error and call os.Exit(2).
+	PanicOnError                         // call panic with the error.
+)
 
 // Value defines environment variable's value behaviours.
 type Value interface {
@@ -22,6 +44,33 @@ type Value interface {
 	Get() any
 }
 
+// Emptier is implemented by values which can be empty, Parse reports
+// ErrEnvironmentVariableIsEmpty for them. Values not implementing it are
+// empty if Get returns an empty string.
+type Emptier interface {
+	IsEmpty() bool
+}
+
+// Validator is implemented by values which check their current value, the
+// default included, after Parse sets it.
+type Validator interface {
+	Validate() error
+}
+
+func isEmptier(v Value) bool {
+	_, ok := v.(Emptier)
+
+	return ok
+}
+
+func isEmptyValue(v Value) bool {
+	if emptier, ok := v.(Emptier); ok {
+		return emptier.IsEmpty()
+	}
+
+	return v.Get() == ""
+}
+
 // compile time proofs.
 var (
 	_ Value = (*boolValue)(nil)
@@ -33,194 +82,851 @@ var (
 	_ Value = (*durationValue)(nil)
 	_ Value = (*tcpAddrValue)(nil)
 	_ Value = (*logLevelValue)(nil)
+	_ Value = (*secretValue)(nil)
+	_ Value = (*parserValue[any])(nil)
+	_ Value = (*sliceValue[any])(nil)
+	_ Value = (*mapValue[any])(nil)
+	_ Value = (*urlValue)(nil)
+	_ Value = (*netipValue[netip.Addr])(nil)
+	_ Value = (*familySliceValue[any])(nil)
+	_ Value = (*udpAddrValue)(nil)
+	_ Value = (*unixAddrValue)(nil)
+
+	_ Emptier   = (*stringValue)(nil)
+	_ Emptier   = (*stringSliceValue)(nil)
+	_ Emptier   = (*tcpAddrValue)(nil)
+	_ Emptier   = (*secretValue)(nil)
+	_ Emptier   = (*sliceValue[any])(nil)
+	_ Emptier   = (*mapValue[any])(nil)
+	_ Emptier   = (*urlValue)(nil)
+	_ Emptier   = (*netipValue[netip.Addr])(nil)
+	_ Emptier   = (*udpAddrValue)(nil)
+	_ Emptier   = (*unixAddrValue)(nil)
+	_ Validator = (*tcpAddrValue)(nil)
+	_ Validator = (*sliceValue[any])(nil)
+	_ Validator = (*urlValue)(nil)
+	_ Validator = (*netipValue[netip.Addr])(nil)
+	_ Validator = (*udpAddrValue)(nil)
+	_ Validator = (*unixAddrValue)(nil)
+
+	_ listValue = (*stringSliceValue)(nil)
+	_ listValue = (*sliceValue[any])(nil)
+	_ listValue = (*mapValue[any])(nil)
+
+	_ keyValueList = (*mapValue[any])(nil)
+
+	_ familyValue = (*netipValue[netip.Addr])(nil)
+	_ familyValue = (*familySliceValue[any])(nil)
+	_ familyValue = (*udpAddrValue)(nil)
+
+	_ sensitiveValue = (*secretValue)(nil)
+
+	_ Restorer = (*stringSliceValue)(nil)
+	_ Restorer = (*logLevelValue)(nil)
+	_ Restorer = (*parserValue[any])(nil)
+	_ Restorer = (*sliceValue[any])(nil)
+	_ Restorer = (*mapValue[any])(nil)
+	_ Restorer = (*urlValue)(nil)
+	_ Restorer = (*udpAddrValue)(nil)
+	_ Restorer = (*netipValue[netip.Addr])(nil)
+
+	_ stager = (*stringSliceValue)(nil)
+	_ stager = (*logLevelValue)(nil)
+	_ stager = (*parserValue[any])(nil)
+	_ stager = (*sliceValue[any])(nil)
+	_ stager = (*mapValue[any])(nil)
+	_ stager = (*urlValue)(nil)
+	_ stager = (*udpAddrValue)(nil)
+	_ stager = (*netipValue[netip.Addr])(nil)
 )
 
 // EnvironmentVariable represents environment variable.
 type EnvironmentVariable struct {
-	Value Value
-	Name  string
+	Value       Value
+	Name        string
+	Description string
+	DefValue    string // default value as text, for usage message.
+	Required    bool
+	Sensitive   bool
+
+	defaultValue any
+	onChange     []func(previous, current any)
+	origin       Origin
+	shareable    bool
+	allowEmpty   bool
+	shared       []*EnvironmentVariable
+	rules        []rule
+	allowed      []string
+}
+
+// String returns "NAME=value", the value is redacted if the variable is
+// sensitive.
+func (e *EnvironmentVariable) String() string { return e.Name + "=" + e.formatValue() }
+
+// LogValue implements slog.LogValuer, the value is redacted if the variable
+// is sensitive.
+func (e *EnvironmentVariable) LogValue() slog.Value { return slog.StringValue(e.formatValue()) }
+
+func (e *EnvironmentVariable) formatValue() string {
+	if e.Sensitive {
+		return redacted
+	}
+
+	return formatValue(e.Value)
 }
 
 // EnvironmentVariableSet mimics flag.FlagSet type.
+//
+// An EnvironmentVariableSet is safe for concurrent use: registration, Parse,
+// Reload, Reset, the accessors and Value may be called from several
+// goroutines. Pointers returned by the typed constructors are written by
+// Parse and Reload without synchronization, read them after Parse returns
+// or use Value or ValueIn while Parse or Reload may run concurrently.
+// Options, Value implementations and the Usage field are not guarded and
+// should be set before the set is shared.
 type EnvironmentVariableSet struct {
-	variables map[string]*EnvironmentVariable
+	// Usage is called by PrintUsage, PrintDefaults is used if it is nil.
+	Usage func()
+
+	output        io.Writer
+	source        Source
+	variables     map[string]*EnvironmentVariable
+	actual        map[string]Presence
+	ordered       []*EnvironmentVariable
+	redefined     []string
+	constraints   []constraint
+	name          string
+	prefix        string
+	fileSuffix    string
+	maxFileSize   int64
+	errorHandling ErrorHandling
+	allowEmpty    bool
+	mu            sync.RWMutex
+}
+
+// Name returns the name of the set.
+func (e *EnvironmentVariableSet) Name() string { return e.name }
+
+// ErrorHandling returns the error handling mode of the set.
+func (e *EnvironmentVariableSet) ErrorHandling() ErrorHandling { return e.errorHandling }
+
+// Output returns the destination of error messages, os.Stderr if not set.
+func (e *EnvironmentVariableSet) Output() io.Writer {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.output == nil {
+		return os.Stderr
+	}
+
+	return e.output
 }
 
-// Var stores EnvironmentVariable type.
-func (e *EnvironmentVariableSet) Var(value Value, name string) {
+// SetOutput sets the destination of error messages, nil means os.Stderr.
+func (e *EnvironmentVariableSet) SetOutput(w io.Writer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.output = w
+}
+
+// Source returns the source of values, OSSource if not set.
+func (e *EnvironmentVariableSet) Source() Source {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.currentSource()
+}
+
+func (e *EnvironmentVariableSet) currentSource() Source {
+	if e.source == nil {
+		return OSSource{}
+	}
+
+	return e.source
+}
+
+// SetSource sets the source of values, nil means OSSource.
+func (e *EnvironmentVariableSet) SetSource(source Source) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.source = source
+}
+
+// Prefix returns the prefix of variable names.
+func (e *EnvironmentVariableSet) Prefix() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.prefix
+}
+
+// SetPrefix sets the prefix of variable names, variables registered
+// afterwards are looked up as PREFIX_NAME.
+func (e *EnvironmentVariableSet) SetPrefix(prefix string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.prefix = prefix
+}
+
+// Sub returns a new set which inherits name, error handling, output, source,
+// file indirection, allow empty and prefix of e, its prefix is extended
+// with the given segment. The new set is independent of e, like any other
+// set, Parse, Reload, usage output and constraints of e don't see its
+// variables, so it has to be parsed on its own.
+func (e *EnvironmentVariableSet) Sub(segment string) *EnvironmentVariableSet {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	sub := NewEnvironmentVariableSet(e.name, e.errorHandling)
+	sub.output = e.output
+	sub.source = e.source
+	sub.fileSuffix = e.fileSuffix
+	sub.maxFileSize = e.maxFileSize
+	sub.allowEmpty = e.allowEmpty
+	sub.prefix = e.resolveName(segment)
+
+	return sub
+}
+
+// resolveName returns the full name of the variable with the prefix.
+func (e *EnvironmentVariableSet) resolveName(name string) string {
+	if e.prefix == "" {
+		return name
+	}
+
+	return e.prefix + "_" + name
+}
+
+// Var stores EnvironmentVariable type, the name is prefixed with the set's
+// prefix. Registering a name again panics, like flag, or is reported by
+// Parse as ErrEnvironmentVariableRedefined if the set uses ContinueOnError.
+// Use Shared option on every registration to share a variable on purpose.
+func (e *EnvironmentVariableSet) Var(value Value, name string, opts ...Option) {
 	envVar := &EnvironmentVariable{
 		Name:  name,
 		Value: value,
 	}
+	if v, ok := value.(sensitiveValue); ok {
+		envVar.Sensitive = v.sensitive()
+	}
+	for _, opt := range opts {
+		opt(envVar)
+	}
+	envVar.DefValue = envVar.formatValue()
+	envVar.defaultValue = value.Get()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	envVar.Name = e.resolveName(name)
 	if e.variables == nil {
 		e.variables = make(map[string]*EnvironmentVariable)
 	}
-	e.variables[name] = envVar
+
+	if existing, ok := e.variables[envVar.Name]; ok {
+		if existing.shareable && envVar.shareable {
+			existing.shared = append(existing.shared, envVar)
+
+			return
+		}
+
+		if e.errorHandling != ContinueOnError {
+			panic(fmt.Sprintf("%s environment variable redefined: %s", e.name, envVar.Name))
+		}
+		e.redefined = append(e.redefined, envVar.Name)
+
+		return
+	}
+
+	e.ordered = append(e.ordered, envVar)
+	e.variables[envVar.Name] = envVar
+}
+
+// registrations returns every registration in order, shared registrations
+// follow the first one.
+func (e *EnvironmentVariableSet) registrations() []*EnvironmentVariable {
+	registrations := make([]*EnvironmentVariable, 0, len(e.ordered))
+	for _, envVar := range e.ordered {
+		registrations = append(registrations, envVar)
+		registrations = append(registrations, envVar.shared...)
+	}
+
+	return registrations
 }
 
 // Bool creates new bool.
-func (e *EnvironmentVariableSet) Bool(name string, value bool) *bool {
+func (e *EnvironmentVariableSet) Bool(name string, value bool, opts ...Option) *bool {
 	p := new(bool)
-	e.BoolVar(p, name, value)
+	e.BoolVar(p, name, value, opts...)
 
 	return p
 }
 
 // Int creates new int.
-func (e *EnvironmentVariableSet) Int(name string, value int) *int {
+func (e *EnvironmentVariableSet) Int(name string, value int, opts ...Option) *int {
 	p := new(int)
-	e.IntVar(p, name, value)
+	e.IntVar(p, name, value, opts...)
 
 	return p
 }
 
 // Int64 creates new int64.
-func (e *EnvironmentVariableSet) Int64(name string, value int64) *int64 {
+func (e *EnvironmentVariableSet) Int64(name string, value int64, opts ...Option) *int64 {
 	p := new(int64)
-	e.Int64Var(p, name, value)
+	e.Int64Var(p, name, value, opts...)
 
 	return p
 }
 
 // Float64 creates new float64.
-func (e *EnvironmentVariableSet) Float64(name string, value float64) *float64 {
+func (e *EnvironmentVariableSet) Float64(name string, value float64, opts ...Option) *float64 {
 	p := new(float64)
-	e.Float64Var(p, name, value)
+	e.Float64Var(p, name, value, opts...)
 
 	return p
 }
 
 // String creates new string.
-func (e *EnvironmentVariableSet) String(name string, value string) *string {
+func (e *EnvironmentVariableSet) String(name string, value string, opts ...Option) *string {
 	p := new(string)
-	e.StringVar(p, name, value)
+	e.StringVar(p, name, value, opts...)
 
 	return p
 }
 
 // Duration creates new duration.
-func (e *EnvironmentVariableSet) Duration(name string, value time.Duration) *time.Duration {
+func (e *EnvironmentVariableSet) Duration(name string, value time.Duration, opts ...Option) *time.Duration {
 	p := new(time.Duration)
-	e.DurationVar(p, name, value)
+	e.DurationVar(p, name, value, opts...)
 
 	return p
 }
 
 // TCPAddr creates new tcp addr.
-func (e *EnvironmentVariableSet) TCPAddr(name string, value string) *string {
+func (e *EnvironmentVariableSet) TCPAddr(name string, value string, opts ...Option) *string {
 	p := new(string)
-	e.TCPAddrVar(p, name, value)
+	e.TCPAddrVar(p, name, value, opts...)
 
 	return p
 }
 
 // StringSlice creates new string slice.
-func (e *EnvironmentVariableSet) StringSlice(name string, value []string) *[]string {
+func (e *EnvironmentVariableSet) StringSlice(name string, value []string, opts ...Option) *[]string {
+	p := new([]string)
+	e.StringSliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// IntSlice creates new int slice.
+func (e *EnvironmentVariableSet) IntSlice(name string, value []int, opts ...Option) *[]int {
+	p := new([]int)
+	e.IntSliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// Int64Slice creates new int64 slice.
+func (e *EnvironmentVariableSet) Int64Slice(name string, value []int64, opts ...Option) *[]int64 {
+	p := new([]int64)
+	e.Int64SliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// Float64Slice creates new float64 slice.
+func (e *EnvironmentVariableSet) Float64Slice(name string, value []float64, opts ...Option) *[]float64 {
+	p := new([]float64)
+	e.Float64SliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// DurationSlice creates new duration slice.
+func (e *EnvironmentVariableSet) DurationSlice(name string, value []time.Duration, opts ...Option) *[]time.Duration {
+	p := new([]time.Duration)
+	e.DurationSliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// TCPAddrSlice creates new tcp addr slice.
+func (e *EnvironmentVariableSet) TCPAddrSlice(name string, value []string, opts ...Option) *[]string {
+	p := new([]string)
+	e.TCPAddrSliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// StringMap creates new string map.
+func (e *EnvironmentVariableSet) StringMap(name string, value map[string]string, opts ...Option) *map[string]string {
+	p := new(map[string]string)
+	e.StringMapVar(p, name, value, opts...)
+
+	return p
+}
+
+// IntMap creates new int map.
+func (e *EnvironmentVariableSet) IntMap(name string, value map[string]int, opts ...Option) *map[string]int {
+	p := new(map[string]int)
+	e.IntMapVar(p, name, value, opts...)
+
+	return p
+}
+
+// DurationMap creates new duration map.
+func (e *EnvironmentVariableSet) DurationMap(
+	name string, value map[string]time.Duration, opts ...Option,
+) *map[string]time.Duration {
+	p := new(map[string]time.Duration)
+	e.DurationMapVar(p, name, value, opts...)
+
+	return p
+}
+
+// URL creates new url, value is parsed as the default.
+func (e *EnvironmentVariableSet) URL(name string, value string, opts ...Option) *url.URL {
+	p := new(url.URL)
+	e.URLVar(p, name, value, opts...)
+
+	return p
+}
+
+// IP creates new ip.
+func (e *EnvironmentVariableSet) IP(name string, value netip.Addr, opts ...Option) *netip.Addr {
+	p := new(netip.Addr)
+	e.IPVar(p, name, value, opts...)
+
+	return p
+}
+
+// IPPrefix creates new ip prefix.
+func (e *EnvironmentVariableSet) IPPrefix(name string, value netip.Prefix, opts ...Option) *netip.Prefix {
+	p := new(netip.Prefix)
+	e.IPPrefixVar(p, name, value, opts...)
+
+	return p
+}
+
+// AddrPort creates new addr port.
+func (e *EnvironmentVariableSet) AddrPort(name string, value netip.AddrPort, opts ...Option) *netip.AddrPort {
+	p := new(netip.AddrPort)
+	e.AddrPortVar(p, name, value, opts...)
+
+	return p
+}
+
+// IPSlice creates new ip slice.
+func (e *EnvironmentVariableSet) IPSlice(name string, value []netip.Addr, opts ...Option) *[]netip.Addr {
+	p := new([]netip.Addr)
+	e.IPSliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// IPPrefixSlice creates new ip prefix slice.
+func (e *EnvironmentVariableSet) IPPrefixSlice(name string, value []netip.Prefix, opts ...Option) *[]netip.Prefix {
+	p := new([]netip.Prefix)
+	e.IPPrefixSliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// AddrPortSlice creates new addr port slice.
+func (e *EnvironmentVariableSet) AddrPortSlice(name string, value []netip.AddrPort, opts ...Option) *[]netip.AddrPort {
+	p := new([]netip.AddrPort)
+	e.AddrPortSliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// UDPAddr creates new udp addr.
+func (e *EnvironmentVariableSet) UDPAddr(name string, value string, opts ...Option) *string {
+	p := new(string)
+	e.UDPAddrVar(p, name, value, opts...)
+
+	return p
+}
+
+// UDPAddrSlice creates new udp addr slice.
+func (e *EnvironmentVariableSet) UDPAddrSlice(name string, value []string, opts ...Option) *[]string {
+	p := new([]string)
+	e.UDPAddrSliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// UnixAddr creates new unix addr.
+func (e *EnvironmentVariableSet) UnixAddr(name string, value string, opts ...Option) *string {
+	p := new(string)
+	e.UnixAddrVar(p, name, value, opts...)
+
+	return p
+}
+
+// UnixAddrSlice creates new unix addr slice.
+func (e *EnvironmentVariableSet) UnixAddrSlice(name string, value []string, opts ...Option) *[]string {
 	p := new([]string)
-	e.StringSliceVar(p, name, value)
+	e.UnixAddrSliceVar(p, name, value, opts...)
+
+	return p
+}
+
+// Secret creates new secret string.
+func (e *EnvironmentVariableSet) Secret(name string, value string, opts ...Option) *SecretString {
+	p := new(SecretString)
+	e.SecretVar(p, name, value, opts...)
 
 	return p
 }
 
 // LogLevel creates new log level.
-func (e *EnvironmentVariableSet) LogLevel(name string, levels map[string]int, value int) *int {
+func (e *EnvironmentVariableSet) LogLevel(name string, levels map[string]int, value int, opts ...Option) *int {
 	p := new(int)
-	e.LogLevelVar(p, name, levels, value)
+	e.LogLevelVar(p, name, levels, value, opts...)
 
 	return p
 }
 
 // BoolVar creates new bool variable.
-func (e *EnvironmentVariableSet) BoolVar(p *bool, name string, value bool) {
-	e.Var(newBoolValue(value, p), name)
+func (e *EnvironmentVariableSet) BoolVar(p *bool, name string, value bool, opts ...Option) {
+	e.Var(newBoolValue(value, p), name, opts...)
 }
 
 // IntVar creates new int variable.
-func (e *EnvironmentVariableSet) IntVar(p *int, name string, value int) {
-	e.Var(newIntValue(value, p), name)
+func (e *EnvironmentVariableSet) IntVar(p *int, name string, value int, opts ...Option) {
+	e.Var(newIntValue(value, p), name, opts...)
 }
 
 // Int64Var creates new int64 variable.
-func (e *EnvironmentVariableSet) Int64Var(p *int64, name string, value int64) {
-	e.Var(newInt64Value(value, p), name)
+func (e *EnvironmentVariableSet) Int64Var(p *int64, name string, value int64, opts ...Option) {
+	e.Var(newInt64Value(value, p), name, opts...)
 }
 
 // Float64Var creates new float64 variable.
-func (e *EnvironmentVariableSet) Float64Var(p *float64, name string, value float64) {
-	e.Var(newFloat64Value(value, p), name)
+func (e *EnvironmentVariableSet) Float64Var(p *float64, name string, value float64, opts ...Option) {
+	e.Var(newFloat64Value(value, p), name, opts...)
 }
 
 // StringVar creates new string variable.
-func (e *EnvironmentVariableSet) StringVar(p *string, name string, value string) {
-	e.Var(newStringValue(value, p), name)
+func (e *EnvironmentVariableSet) StringVar(p *string, name string, value string, opts ...Option) {
+	e.Var(newStringValue(value, p), name, opts...)
 }
 
 // DurationVar creates new duration variable.
-func (e *EnvironmentVariableSet) DurationVar(p *time.Duration, name string, value time.Duration) {
-	e.Var(newDurationValue(value, p), name)
+func (e *EnvironmentVariableSet) DurationVar(p *time.Duration, name string, value time.Duration, opts ...Option) {
+	e.Var(newDurationValue(value, p), name, opts...)
 }
 
 // TCPAddrVar creates new string variable for tcp address value.
-func (e *EnvironmentVariableSet) TCPAddrVar(p *string, name string, value string) {
-	e.Var(newTCPAddrValue(value, p), name)
+func (e *EnvironmentVariableSet) TCPAddrVar(p *string, name string, value string, opts ...Option) {
+	e.Var(newTCPAddrValue(value, p), name, opts...)
+}
+
+// StringSliceVar creates new string slice variable, the value is split on
+// commas unless the Separator option is given, see KeepEmpty too.
+func (e *EnvironmentVariableSet) StringSliceVar(p *[]string, name string, value []string, opts ...Option) {
+	e.Var(newStringSliceValue(value, p), name, opts...)
+}
+
+// IntSliceVar creates new int slice variable, elements are parsed like
+// IntVar. The list options like Separator apply to every slice variable.
+func (e *EnvironmentVariableSet) IntSliceVar(p *[]int, name string, value []int, opts ...Option) {
+	e.Var(newIntSliceValue(value, p), name, opts...)
+}
+
+// Int64SliceVar creates new int64 slice variable.
+func (e *EnvironmentVariableSet) Int64SliceVar(p *[]int64, name string, value []int64, opts ...Option) {
+	e.Var(newInt64SliceValue(value, p), name, opts...)
+}
+
+// Float64SliceVar creates new float64 slice variable.
+func (e *EnvironmentVariableSet) Float64SliceVar(p *[]float64, name string, value []float64, opts ...Option) {
+	e.Var(newFloat64SliceValue(value, p), name, opts...)
+}
+
+// DurationSliceVar creates new duration slice variable.
+func (e *EnvironmentVariableSet) DurationSliceVar(
+	p *[]time.Duration, name string, value []time.Duration, opts ...Option,
+) {
+	e.Var(newDurationSliceValue(value, p), name, opts...)
+}
+
+// TCPAddrSliceVar creates new string slice variable for tcp address values,
+// every element is validated like TCPAddrVar.
+func (e *EnvironmentVariableSet) TCPAddrSliceVar(p *[]string, name string, value []string, opts ...Option) {
+	e.Var(newTCPAddrSliceValue(value, p), name, opts...)
+}
+
+// StringMapVar creates new string map variable from "key=value,key=value"
+// formatted pairs, see Separator and KeyValueSeparator. Duplicate keys are
+// reported as errors.
+func (e *EnvironmentVariableSet) StringMapVar(
+	p *map[string]string, name string, value map[string]string, opts ...Option,
+) {
+	e.Var(newStringMapValue(value, p), name, opts...)
+}
+
+// IntMapVar creates new int map variable, values are parsed like IntVar.
+func (e *EnvironmentVariableSet) IntMapVar(p *map[string]int, name string, value map[string]int, opts ...Option) {
+	e.Var(newIntMapValue(value, p), name, opts...)
+}
+
+// DurationMapVar creates new duration map variable, values are parsed like
+// DurationVar.
+func (e *EnvironmentVariableSet) DurationMapVar(
+	p *map[string]time.Duration, name string, value map[string]time.Duration, opts ...Option,
+) {
+	e.Var(newDurationMapValue(value, p), name, opts...)
+}
+
+// URLVar creates new url variable, see AllowedSchemes, RequireHost,
+// RequireUserinfo, ForbidUserinfo and DefaultPort. The password is redacted
+// in usage output, and parse errors don't have the URL. It panics if value
+// is not a URL.
+func (e *EnvironmentVariableSet) URLVar(p *url.URL, name string, value string, opts ...Option) {
+	e.Var(newURLValue(mustParseURL(value), p), name, opts...)
+}
+
+// IPVar creates new ip variable, see IPv4Only and IPv6Only.
+func (e *EnvironmentVariableSet) IPVar(p *netip.Addr, name string, value netip.Addr, opts ...Option) {
+	e.Var(newIPValue(value, p, new(addrFamily)), name, opts...)
+}
+
+// IPPrefixVar creates new ip prefix variable, like 10.0.0.0/8.
+func (e *EnvironmentVariableSet) IPPrefixVar(p *netip.Prefix, name string, value netip.Prefix, opts ...Option) {
+	e.Var(newIPPrefixValue(value, p, new(addrFamily)), name, opts...)
+}
+
+// AddrPortVar creates new addr port variable, like 127.0.0.1:8080.
+func (e *EnvironmentVariableSet) AddrPortVar(p *netip.AddrPort, name string, value netip.AddrPort, opts ...Option) {
+	e.Var(newAddrPortValue(value, p, new(addrFamily)), name, opts...)
+}
+
+// IPSliceVar creates new ip slice variable.
+func (e *EnvironmentVariableSet) IPSliceVar(p *[]netip.Addr, name string, value []netip.Addr, opts ...Option) {
+	e.Var(newIPSliceValue(value, p), name, opts...)
+}
+
+// IPPrefixSliceVar creates new ip prefix slice variable.
+func (e *EnvironmentVariableSet) IPPrefixSliceVar(
+	p *[]netip.Prefix, name string, value []netip.Prefix, opts ...Option,
+) {
+	e.Var(newIPPrefixSliceValue(value, p), name, opts...)
+}
+
+// AddrPortSliceVar creates new addr port slice variable.
+func (e *EnvironmentVariableSet) AddrPortSliceVar(
+	p *[]netip.AddrPort, name string, value []netip.AddrPort, opts ...Option,
+) {
+	e.Var(newAddrPortSliceValue(value, p), name, opts...)
+}
+
+// UDPAddrVar creates new string variable for udp address value.
+func (e *EnvironmentVariableSet) UDPAddrVar(p *string, name string, value string, opts ...Option) {
+	e.Var(newUDPAddrValue(value, p, new(addrFamily)), name, opts...)
 }
 
-// StringSliceVar creates new string slice variable.
-func (e *EnvironmentVariableSet) StringSliceVar(p *[]string, name string, value []string) {
-	e.Var(newStringSliceValue(value, p), name)
+// UDPAddrSliceVar creates new string slice variable for udp address values.
+func (e *EnvironmentVariableSet) UDPAddrSliceVar(p *[]string, name string, value []string, opts ...Option) {
+	e.Var(newUDPAddrSliceValue(value, p), name, opts...)
+}
+
+// UnixAddrVar creates new string variable for unix socket path value.
+func (e *EnvironmentVariableSet) UnixAddrVar(p *string, name string, value string, opts ...Option) {
+	e.Var(newUnixAddrValue(value, p), name, opts...)
+}
+
+// UnixAddrSliceVar creates new string slice variable for unix socket path values.
+func (e *EnvironmentVariableSet) UnixAddrSliceVar(p *[]string, name string, value []string, opts ...Option) {
+	e.Var(newUnixAddrSliceValue(value, p), name, opts...)
+}
+
+// SecretVar creates new secret string variable, it is always sensitive.
+func (e *EnvironmentVariableSet) SecretVar(p *SecretString, name string, value string, opts ...Option) {
+	e.Var(newSecretValue(value, p), name, opts...)
 }
 
 // LogLevelVar creates new log level variable.
-func (e *EnvironmentVariableSet) LogLevelVar(p *int, name string, levels map[string]int, value int) {
-	e.Var(newLogLevelValue(levels, value, p), name)
+func (e *EnvironmentVariableSet) LogLevelVar(p *int, name string, levels map[string]int, value int, opts ...Option) {
+	e.Var(newLogLevelValue(levels, value, p), name, opts...)
 }
 
-// Parse fetches environment variable, creates required Value, sets and stores.
+// Parse fetches environment variables, sets and validates their values in
+// registration order, then checks the constraints between them. It checks
+// every registered variable and returns ParseErrors listing all the
+// failures. Sets with ExitOnError or PanicOnError exit or panic on failure
+// instead of returning.
 func (e *EnvironmentVariableSet) Parse() error {
-	for name, envVar := range e.variables {
-		envValue := os.Getenv(name)
-
-		// if environment variable is not empty.
-		if envValue != "" {
-			// set the environment variable's value.
-			if err := envVar.Value.Set(envValue); err != nil {
-				return fmt.Errorf("%q %w", name, err)
-			}
+	err := e.parse()
+	if err == nil {
+		return nil
+	}
+
+	switch e.errorHandling {
+	case ContinueOnError:
+	case ExitOnError:
+		fmt.Fprintln(e.Output(), err)
+		os.Exit(exitCodeParseError)
+	case PanicOnError:
+		panic(err)
+	}
+
+	return err
+}
+
+func (e *EnvironmentVariableSet) parse() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.parseVariables(e.registrations())
+}
+
+// parseVariables parses the registrations, which are the registrations of
+// the set or their staged copies.
+func (e *EnvironmentVariableSet) parseVariables(registrations []*EnvironmentVariable) error {
+	var errs ParseErrors
+
+	for _, name := range e.redefined {
+		errs = append(errs, &ParseError{Name: name, Err: ErrEnvironmentVariableRedefined})
+	}
+
+	e.actual = make(map[string]Presence)
+
+	source := e.currentSource()
+	for _, envVar := range registrations {
+		if err := e.parseEnvironmentVariable(envVar, source); err != nil {
+			errs = append(errs, &ParseError{Name: envVar.Name, Err: err})
 		}
+	}
+
+	errs = append(errs, e.checkConstraints(source)...)
+
+	if len(errs) == 0 {
+		return nil
+	}
 
-		// if the current value is empty?
-		if envVar.Value.Get() == "" {
-			return fmt.Errorf("%q %w", name, ErrEnvironmentVariableIsEmpty)
+	return errs
+}
+
+func (e *EnvironmentVariableSet) parseEnvironmentVariable(envVar *EnvironmentVariable, source Source) error {
+	envVar.origin = OriginDefault
+
+	envValue, origin, found, err := e.lookup(source, envVar.Name)
+	if err != nil {
+		return err
+	}
+	if !found && envVar.Required {
+		return ErrEnvironmentVariableNotFound
+	}
+	if found {
+		if _, ok := e.actual[envVar.Name]; !ok {
+			e.actual[envVar.Name] = presenceOf(envValue)
 		}
+	}
+
+	allowEmpty := envVar.allowEmpty || e.allowEmpty
 
-		// check if string slice is empty.
-		if v, ok := envVar.Value.(*stringSliceValue); ok {
-			if slice, okay := v.Get().([]string); okay && len(slice) == 0 {
-				return fmt.Errorf("%q %w", name, ErrEnvironmentVariableIsEmpty)
+	// if environment variable is not empty, or explicitly set to empty.
+	if envValue != "" || (found && allowEmpty && isEmptier(envVar.Value)) {
+		// set the environment variable's value.
+		if err = envVar.Value.Set(envValue); err != nil {
+			if envVar.Sensitive {
+				return redactError(err)
 			}
+
+			return fmt.Errorf("%w", err)
+		}
+		envVar.origin = origin
+	}
+
+	if isEmptyValue(envVar.Value) {
+		if allowEmpty {
+			return nil
 		}
 
-		if v, ok := envVar.Value.(*tcpAddrValue); ok {
-			if val, okay := v.Get().(string); okay {
-				if _, err := ValidateTCPNetworkAddress(val); err != nil {
-					return fmt.Errorf("%q [%w] %w", name, ErrInvalid, err)
-				}
+		return ErrEnvironmentVariableIsEmpty
+	}
+
+	if v, ok := envVar.Value.(Validator); ok {
+		if err = v.Validate(); err != nil {
+			if envVar.Sensitive {
+				return redactError(err)
 			}
+
+			return fmt.Errorf("%w", err)
 		}
 	}
 
-	return nil
+	return envVar.checkRules()
 }
 
-// Reset resets variables storage.
+// LogValue implements slog.LogValuer, it groups the current values of the
+// variables in registration order, sensitive values are redacted.
+func (e *EnvironmentVariableSet) LogValue() slog.Value {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	attrs := make([]slog.Attr, 0, len(e.ordered))
+	for _, envVar := range e.ordered {
+		attrs = append(attrs, slog.Any(envVar.Name, envVar))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// VisitAll calls fn for every variable in registration order.
+func (e *EnvironmentVariableSet) VisitAll(fn func(*EnvironmentVariable)) {
+	e.mu.RLock()
+	ordered := slices.Clone(e.ordered)
+	e.mu.RUnlock()
+
+	for _, envVar := range ordered {
+		fn(envVar)
+	}
+}
+
+// Visit calls fn, in registration order, for the variables which were found
+// in the source during the last Parse or Reload.
+func (e *EnvironmentVariableSet) Visit(fn func(*EnvironmentVariable)) {
+	e.mu.RLock()
+	ordered := make([]*EnvironmentVariable, 0, len(e.actual))
+	for _, envVar := range e.ordered {
+		if _, ok := e.actual[envVar.Name]; ok {
+			ordered = append(ordered, envVar)
+		}
+	}
+	e.mu.RUnlock()
+
+	for _, envVar := range ordered {
+		fn(envVar)
+	}
+}
+
+// Reset resets variables and constraints storage.
 func (e *EnvironmentVariableSet) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	if e.variables != nil {
 		e.variables = make(map[string]*EnvironmentVariable)
 	}
+	e.actual = nil
+	e.ordered = nil
+	e.redefined = nil
+	e.constraints = nil
 }
 
-func newEnvironmentVariableSet() *EnvironmentVariableSet {
-	return &EnvironmentVariableSet{}
+// NewEnvironmentVariableSet returns a new, empty environment variable set
+// with the given name and error handling mode.
+func NewEnvironmentVariableSet(name string, errorHandling ErrorHandling) *EnvironmentVariableSet {
+	return &EnvironmentVariableSet{
+		name:          name,
+		errorHandling: errorHandling,
+	}
 }
 
 // Parse handles environment variable set/assign operations.
@@ -236,3 +942,20 @@ func Parse() error {
 func Reset() {
 	environmentVariableSetInstance.Reset()
 }
+
+// VisitAll calls fn for every variable of the global set in registration
+// order.
+func VisitAll(fn func(*EnvironmentVariable)) {
+	environmentVariableSetInstance.VisitAll(fn)
+}
+
+// Visit calls fn for the variables of the global set which were found in
+// the source.
+func Visit(fn func(*EnvironmentVariable)) {
+	environmentVariableSetInstance.Visit(fn)
+}
+
+// SetPrefix sets the prefix of variable names of the global set.
+func SetPrefix(prefix string) {
+	environmentVariableSetInstance.SetPrefix(prefix)
+}
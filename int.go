@@ -25,7 +25,9 @@ func (i *intValue) Set(s string) error {
 
 func (i *intValue) Get() any { return int(*i) }
 
+func (i *intValue) Type() string { return "int" }
+
 // Int sets environment variable and returns the pointer of value.
-func Int(name string, value int) *int {
-	return environmentVariableSetInstance.Int(name, value)
+func Int(name string, value int, opts ...Option) *int {
+	return environmentVariableSetInstance.Int(name, value, opts...)
 }
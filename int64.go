@@ -25,7 +25,9 @@ func (i *int64Value) Set(s string) error {
 
 func (i *int64Value) Get() any { return int64(*i) }
 
+func (i *int64Value) Type() string { return "int64" }
+
 // Int64 sets environment variable and returns the pointer of value.
-func Int64(name string, value int64) *int64 {
-	return environmentVariableSetInstance.Int64(name, value)
+func Int64(name string, value int64, opts ...Option) *int64 {
+	return environmentVariableSetInstance.Int64(name, value, opts...)
 }
@@ -0,0 +1,170 @@
+package getenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultSeparator separates the elements of list values.
+const defaultSeparator = ","
+
+// listValue is implemented by values which split their text into elements,
+// the list options configure them.
+type listValue interface {
+	listConfig() *listConfig
+}
+
+// listConfig splits the text of list values. Elements are trimmed, they
+// can be double quoted to keep the separator or the surrounding spaces, a
+// backslash escapes the separator or a quote.
+type listConfig struct {
+	separator string // empty means whitespace.
+	keepEmpty bool
+}
+
+func newListConfig() listConfig { return listConfig{separator: defaultSeparator} }
+
+// Separator sets the separator of list values like StringSlice, ",", by
+// default. Empty separator splits on any whitespace, newlines included. It
+// panics if the value is not a list.
+func Separator(sep string) Option {
+	return listOption("Separator", func(c *listConfig) { c.separator = sep })
+}
+
+// KeepEmpty keeps the empty elements of list values, "a,,b" has three
+// elements. Whitespace separated lists never have empty elements. Typed
+// slices and maps report an empty element as invalid. It panics if the value
+// is not a list.
+func KeepEmpty() Option {
+	return listOption("KeepEmpty", func(c *listConfig) { c.keepEmpty = true })
+}
+
+func listOption(option string, fn func(*listConfig)) Option {
+	return func(e *EnvironmentVariable) {
+		l, ok := e.Value.(listValue)
+		if !ok {
+			panic(fmt.Sprintf("getenv: %s option is not supported by %s", option, e.Name))
+		}
+		fn(l.listConfig())
+	}
+}
+
+// separatorAt returns the length of the separator at i, zero if there is
+// none.
+func (c *listConfig) separatorAt(val string, i int) int {
+	if c.separator != "" {
+		if strings.HasPrefix(val[i:], c.separator) {
+			return len(c.separator)
+		}
+
+		return 0
+	}
+
+	n := 0
+	for i+n < len(val) && isListSpace(val[i+n]) {
+		n++
+	}
+
+	return n
+}
+
+// split splits val into elements, blank text has no elements.
+func (c *listConfig) split(val string) ([]string, error) {
+	elements := []string{}
+	if strings.TrimSpace(val) == "" {
+		return elements, nil
+	}
+
+	var (
+		b      strings.Builder
+		quoted bool
+	)
+
+	flush := func() {
+		element := b.String()
+		if !quoted {
+			element = strings.TrimSpace(element)
+		}
+		if element != "" || quoted || (c.keepEmpty && c.separator != "") {
+			elements = append(elements, element)
+		}
+		b.Reset()
+		quoted = false
+	}
+
+	for i := 0; i < len(val); {
+		if n := c.separatorAt(val, i); n > 0 {
+			flush()
+			i += n
+
+			continue
+		}
+
+		ch := val[i]
+
+		switch {
+		case quoted:
+			if !isListSpace(ch) {
+				return nil, fmt.Errorf("[%w] element %d unexpected %q after quote", ErrInvalid, len(elements), ch)
+			}
+			i++
+
+			continue
+		case ch == '"' && strings.TrimSpace(b.String()) == "":
+			element, end, ok := unquoteListElement(val, i)
+			if !ok {
+				return nil, fmt.Errorf("[%w] element %d unterminated quote", ErrInvalid, len(elements))
+			}
+			b.Reset()
+			b.WriteString(element)
+			quoted = true
+			i = end
+
+			continue
+		case ch == '\\' && i+1 < len(val):
+			if n := c.separatorAt(val, i+1); n > 0 {
+				b.WriteString(val[i+1 : i+1+n])
+				i += 1 + n
+
+				continue
+			}
+			if val[i+1] == '"' {
+				b.WriteByte('"')
+				i += 2
+
+				continue
+			}
+		}
+
+		b.WriteByte(ch)
+		i++
+	}
+	flush()
+
+	return elements, nil
+}
+
+// unquoteListElement reads the double quoted element starting at i, \" and
+// \\ are unescaped. It returns the element and the position after it, false
+// if the quote is not terminated.
+func unquoteListElement(val string, i int) (string, int, bool) {
+	var b strings.Builder
+
+	for j := i + 1; j < len(val); j++ {
+		switch ch := val[j]; {
+		case ch == '"':
+			return b.String(), j + 1, true
+		case ch == '\\' && j+1 < len(val) && (val[j+1] == '"' || val[j+1] == '\\'):
+			b.WriteByte(val[j+1])
+			j++
+		default:
+			b.WriteByte(ch)
+		}
+	}
+
+	return "", 0, false
+}
+
+func isListSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
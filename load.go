@@ -0,0 +1,270 @@
+package getenv
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// struct tags used by Load.
+const (
+	tagEnv         = "env"
+	tagDefault     = "default"
+	tagRequired    = "required"
+	tagPrefix      = "prefix"
+	tagType        = "type"
+	tagLevels      = "levels"
+	tagDescription = "description"
+	tagSensitive   = "sensitive"
+	tagAllowEmpty  = "allowempty"
+	tagSeparator   = "separator"
+	tagKeepEmpty   = "keepempty"
+	tagKVSeparator = "kvseparator"
+)
+
+// values of the type tag.
+const (
+	fieldTypeTCPAddr  = "tcpaddr"
+	fieldTypeUDPAddr  = "udpaddr"
+	fieldTypeUnixAddr = "unixaddr"
+	fieldTypeLogLevel = "loglevel"
+)
+
+// Load registers the exported fields of the struct pointed by v and calls
+// Parse. Fields are configured with struct tags:
+//
+//	type Config struct {
+//		Port     int               `env:"PORT" default:"8000"`
+//		Timeout  time.Duration     `env:"TIMEOUT" default:"5s" required:"true"`
+//		Password string            `env:"PASSWORD" sensitive:"true"`
+//		Suffix   string            `env:"SUFFIX" allowempty:"true"`
+//		Path     []string          `env:"PATH" separator:":"`
+//		Labels   map[string]string `env:"LABELS" kvseparator:":"`
+//		Listen   string            `env:"LISTEN" default:":4000" type:"tcpaddr"`
+//		Socket   string            `env:"SOCKET" default:"/run/app.sock" type:"unixaddr"`
+//		LogLevel int               `env:"LOG_LEVEL" default:"INFO" type:"loglevel" levels:"DEBUG=0,INFO=1"`
+//		Database struct {
+//			Host string `env:"HOST" default:"localhost"`
+//		} `prefix:"DB_"`
+//	}
+//
+// Besides the built-in types, any type registered with RegisterParser can be
+// used as a field type. Nested structs are walked recursively, their variable
+// names are prefixed with the prefix tag. Fields without the env tag are
+// skipped.
+func (e *EnvironmentVariableSet) Load(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("[%w] Load expects a pointer to struct, got %T", ErrInvalid, v)
+	}
+
+	if err := e.loadStruct(rv.Elem(), ""); err != nil {
+		return err
+	}
+
+	return e.Parse()
+}
+
+func (e *EnvironmentVariableSet) loadStruct(rv reflect.Value, prefix string) error {
+	rt := rv.Type()
+
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldValue := rv.Field(i)
+		envName, hasEnv := field.Tag.Lookup(tagEnv)
+
+		if !hasEnv {
+			if field.Type.Kind() == reflect.Struct {
+				if err := e.loadStruct(fieldValue, prefix+field.Tag.Get(tagPrefix)); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		name := prefix + envName
+
+		value, err := newFieldValue(fieldValue, field.Tag)
+		if err != nil {
+			return fmt.Errorf("%q field %s %w", name, field.Name, err)
+		}
+
+		if err = applyListTags(value, field.Tag); err != nil {
+			return fmt.Errorf("%q field %s %w", name, field.Name, err)
+		}
+
+		if def, ok := field.Tag.Lookup(tagDefault); ok {
+			if err = value.Set(def); err != nil {
+				return fmt.Errorf("%q field %s default %w", name, field.Name, err)
+			}
+		}
+
+		var opts []Option
+
+		if description, ok := field.Tag.Lookup(tagDescription); ok {
+			opts = append(opts, Description(description))
+		}
+
+		for _, flag := range []struct {
+			opt Option
+			tag string
+		}{
+			{tag: tagRequired, opt: Required()},
+			{tag: tagSensitive, opt: Sensitive()},
+			{tag: tagAllowEmpty, opt: AllowEmpty()},
+		} {
+			val, ok := field.Tag.Lookup(flag.tag)
+			if !ok {
+				continue
+			}
+
+			enabled, errBool := strconv.ParseBool(val)
+			if errBool != nil {
+				return fmt.Errorf("%q field %s [%w] %s tag %w", name, field.Name, ErrInvalid, flag.tag, errBool)
+			}
+			if enabled {
+				opts = append(opts, flag.opt)
+			}
+		}
+
+		e.Var(value, name, opts...)
+	}
+
+	return nil
+}
+
+func newFieldValue(fieldValue reflect.Value, tag reflect.StructTag) (Value, error) {
+	ptr := fieldValue.Addr().Interface()
+
+	switch fieldType := tag.Get(tagType); fieldType {
+	case "":
+	case fieldTypeTCPAddr, fieldTypeUDPAddr, fieldTypeUnixAddr:
+		if value := newAddrFieldValue(fieldType, ptr); value != nil {
+			return value, nil
+		}
+
+		return nil, fmt.Errorf("[%w] type %q requires string or []string, got %s", ErrInvalid, fieldType, fieldValue.Type())
+	case fieldTypeLogLevel:
+		p, ok := ptr.(*int)
+		if !ok {
+			return nil, fmt.Errorf("[%w] type %q requires int, got %s", ErrInvalid, fieldType, fieldValue.Type())
+		}
+
+		levels, err := parseLevelsTag(tag.Get(tagLevels))
+		if err != nil {
+			return nil, err
+		}
+
+		return newLogLevelValue(levels, *p, p), nil
+	default:
+		return nil, fmt.Errorf("[%w] unknown type %q", ErrInvalid, fieldType)
+	}
+
+	factory, ok := valueRegistryInstance.get(fieldValue.Type())
+	if !ok {
+		return nil, fmt.Errorf("[%w] unsupported type %s", ErrInvalid, fieldValue.Type())
+	}
+
+	return factory(ptr), nil
+}
+
+// newAddrFieldValue returns the address value of the type tag for string
+// and []string fields, nil for other fields.
+func newAddrFieldValue(fieldType string, ptr any) Value {
+	switch p := ptr.(type) {
+	case *string:
+		switch fieldType {
+		case fieldTypeTCPAddr:
+			return newTCPAddrValue(*p, p)
+		case fieldTypeUDPAddr:
+			return newUDPAddrValue(*p, p, new(addrFamily))
+		case fieldTypeUnixAddr:
+			return newUnixAddrValue(*p, p)
+		}
+	case *[]string:
+		switch fieldType {
+		case fieldTypeTCPAddr:
+			return newTCPAddrSliceValue(*p, p)
+		case fieldTypeUDPAddr:
+			return newUDPAddrSliceValue(*p, p)
+		case fieldTypeUnixAddr:
+			return newUnixAddrSliceValue(*p, p)
+		}
+	}
+
+	return nil
+}
+
+// applyListTags configures list values with the separator and keepempty
+// tags and map values with the kvseparator tag, before the default is set.
+func applyListTags(value Value, tag reflect.StructTag) error {
+	if kvSeparator, ok := tag.Lookup(tagKVSeparator); ok {
+		m, isMap := value.(keyValueList)
+		if !isMap || kvSeparator == "" {
+			return fmt.Errorf("[%w] %s tag requires a map and a separator", ErrInvalid, tagKVSeparator)
+		}
+		*m.keyValueSeparator() = kvSeparator
+	}
+
+	separator, hasSeparator := tag.Lookup(tagSeparator)
+	keepEmpty, hasKeepEmpty := tag.Lookup(tagKeepEmpty)
+	if !hasSeparator && !hasKeepEmpty {
+		return nil
+	}
+
+	l, ok := value.(listValue)
+	if !ok {
+		return fmt.Errorf("[%w] %s and %s tags require a list", ErrInvalid, tagSeparator, tagKeepEmpty)
+	}
+
+	config := l.listConfig()
+	if hasSeparator {
+		config.separator = separator
+	}
+	if hasKeepEmpty {
+		enabled, err := strconv.ParseBool(keepEmpty)
+		if err != nil {
+			return fmt.Errorf("[%w] %s tag %w", ErrInvalid, tagKeepEmpty, err)
+		}
+		config.keepEmpty = enabled
+	}
+
+	return nil
+}
+
+// parseLevelsTag parses "DEBUG=0,INFO=1" formatted levels tag.
+func parseLevelsTag(tag string) (map[string]int, error) {
+	levels := make(map[string]int)
+
+	for pair := range strings.SplitSeq(tag, ",") {
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("[%w] levels tag %q", ErrInvalid, pair)
+		}
+
+		level, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("[%w] levels tag %w", ErrInvalid, err)
+		}
+
+		levels[strings.TrimSpace(key)] = level
+	}
+
+	return levels, nil
+}
+
+// Load registers the fields of the struct pointed by v and parses the
+// environment.
+func Load(v any) error {
+	if err := environmentVariableSetInstance.Load(v); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
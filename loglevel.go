@@ -1,7 +1,9 @@
 package getenv
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -35,7 +37,52 @@ func (l *logLevelValue) Set(s string) error {
 
 func (l *logLevelValue) Get() any { return *l.val }
 
+func (l *logLevelValue) Type() string { return "loglevel" }
+
+func (l *logLevelValue) stage() Value {
+	staged := *l
+	staged.val = new(int)
+
+	return &staged
+}
+
+func (l *logLevelValue) Restore(v any) error {
+	level, ok := v.(int)
+	if !ok {
+		return fmt.Errorf("[%w] can not restore %T as log level", ErrInvalid, v)
+	}
+	*l.val = level
+
+	return nil
+}
+
+// String returns the name of the current level, or the number if it has no
+// name.
+func (l *logLevelValue) String() string {
+	for _, name := range l.AllowedValues() {
+		if l.levels[name] == *l.val {
+			return name
+		}
+	}
+
+	return fmt.Sprint(*l.val)
+}
+
+// AllowedValues returns the level names sorted by level.
+func (l *logLevelValue) AllowedValues() []string {
+	names := make([]string, 0, len(l.levels))
+	for name := range l.levels {
+		names = append(names, name)
+	}
+
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(l.levels[a], l.levels[b]), strings.Compare(a, b))
+	})
+
+	return names
+}
+
 // LogLevel sets environment variable and returns the pointer of value.
-func LogLevel(name string, levels map[string]int, defaultValue int) *int {
-	return environmentVariableSetInstance.LogLevel(name, levels, defaultValue)
+func LogLevel(name string, levels map[string]int, defaultValue int, opts ...Option) *int {
+	return environmentVariableSetInstance.LogLevel(name, levels, defaultValue, opts...)
 }
@@ -0,0 +1,141 @@
+package getenv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// defaultKeyValueSeparator separates the key and the value of map pairs.
+const defaultKeyValueSeparator = "="
+
+// keyValueList is implemented by values which split their elements into a
+// key and a value.
+type keyValueList interface {
+	keyValueSeparator() *string
+}
+
+// KeyValueSeparator sets the separator of the key and the value of map
+// variables like StringMap, "=" by default. It panics if the value is not a
+// map.
+func KeyValueSeparator(sep string) Option {
+	return func(e *EnvironmentVariable) {
+		m, ok := e.Value.(keyValueList)
+		if !ok {
+			panic(fmt.Sprintf("getenv: KeyValueSeparator option is not supported by %s", e.Name))
+		}
+		if sep == "" {
+			panic("getenv: KeyValueSeparator can not be empty")
+		}
+		*m.keyValueSeparator() = sep
+	}
+}
+
+// mapValue is a map of values, the pairs are split like a list and each
+// value is parsed by the Value of its scalar type.
+type mapValue[V any] struct {
+	p       *map[string]V
+	newElem func(p *V) Value
+	kvSep   string
+	list    listConfig
+}
+
+func newMapValue[V any](val map[string]V, p *map[string]V, newElem func(p *V) Value) *mapValue[V] {
+	*p = val
+
+	return &mapValue[V]{p: p, newElem: newElem, kvSep: defaultKeyValueSeparator, list: newListConfig()}
+}
+
+func (m *mapValue[V]) Set(val string) error {
+	pairs, err := m.list.split(val)
+	if err != nil {
+		return err
+	}
+
+	result := make(map[string]V, len(pairs))
+	for i, pair := range pairs {
+		key, text, ok := strings.Cut(pair, m.kvSep)
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("[%w] pair %d %q is not key%svalue", ErrInvalid, i, pair, m.kvSep)
+		}
+		if _, exists := result[key]; exists {
+			return fmt.Errorf("[%w] duplicate key %q", ErrInvalid, key)
+		}
+
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return fmt.Errorf("[%w] key %q has an empty value", ErrInvalid, key)
+		}
+
+		var elem V
+		if err = m.newElem(&elem).Set(text); err != nil {
+			return fmt.Errorf("key %q %w", key, err)
+		}
+		result[key] = elem
+	}
+
+	*m.p = result
+
+	return nil
+}
+
+func (m *mapValue[V]) Get() any { return *m.p }
+
+func (m *mapValue[V]) Type() string {
+	var zero V
+
+	return "map[string]" + typeName(m.newElem(&zero))
+}
+
+func (m *mapValue[V]) IsEmpty() bool { return len(*m.p) == 0 }
+
+func (m *mapValue[V]) stage() Value {
+	staged := *m
+	staged.p = new(map[string]V)
+
+	return &staged
+}
+
+func (m *mapValue[V]) Restore(v any) error {
+	val, ok := v.(map[string]V)
+	if !ok {
+		return fmt.Errorf("[%w] can not restore %T as %s", ErrInvalid, v, reflect.TypeFor[map[string]V]())
+	}
+
+	*m.p = val
+
+	return nil
+}
+
+func (m *mapValue[V]) listConfig() *listConfig { return &m.list }
+
+func (m *mapValue[V]) keyValueSeparator() *string { return &m.kvSep }
+
+func newStringMapValue(val map[string]string, p *map[string]string) *mapValue[string] {
+	return newMapValue(val, p, func(p *string) Value { return (*stringValue)(p) })
+}
+
+func newIntMapValue(val map[string]int, p *map[string]int) *mapValue[int] {
+	return newMapValue(val, p, func(p *int) Value { return (*intValue)(p) })
+}
+
+func newDurationMapValue(val map[string]time.Duration, p *map[string]time.Duration) *mapValue[time.Duration] {
+	return newMapValue(val, p, func(p *time.Duration) Value { return (*durationValue)(p) })
+}
+
+// StringMap sets environment variable and returns the pointer of value.
+func StringMap(name string, value map[string]string, opts ...Option) *map[string]string {
+	return environmentVariableSetInstance.StringMap(name, value, opts...)
+}
+
+// IntMap sets environment variable and returns the pointer of value.
+func IntMap(name string, value map[string]int, opts ...Option) *map[string]int {
+	return environmentVariableSetInstance.IntMap(name, value, opts...)
+}
+
+// DurationMap sets environment variable and returns the pointer of value.
+func DurationMap(name string, value map[string]time.Duration, opts ...Option) *map[string]time.Duration {
+	return environmentVariableSetInstance.DurationMap(name, value, opts...)
+}
@@ -0,0 +1,230 @@
+package getenv
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+)
+
+// addrFamily restricts addresses to IPv4 or IPv6.
+type addrFamily int
+
+// address families.
+const (
+	familyAny addrFamily = iota
+	familyIPv4
+	familyIPv6
+)
+
+// familyValue is implemented by values restricted by IPv4Only and IPv6Only.
+type familyValue interface {
+	addrFamily() *addrFamily
+}
+
+// IPv4Only accepts only IPv4 addresses, it works with IP, IPPrefix,
+// AddrPort, UDPAddr and their slices. It panics for other values.
+func IPv4Only() Option { return familyOption("IPv4Only", familyIPv4) }
+
+// IPv6Only accepts only IPv6 addresses, see IPv4Only.
+func IPv6Only() Option { return familyOption("IPv6Only", familyIPv6) }
+
+func familyOption(option string, family addrFamily) Option {
+	return func(e *EnvironmentVariable) {
+		f, ok := e.Value.(familyValue)
+		if !ok {
+			panic(fmt.Sprintf("getenv: %s option is not supported by %s", option, e.Name))
+		}
+		*f.addrFamily() = family
+	}
+}
+
+func (f addrFamily) check(addr netip.Addr) error {
+	switch f {
+	case familyIPv4:
+		if !addr.Is4() {
+			return fmt.Errorf("[%w] %s is not an IPv4 address", ErrInvalid, addr)
+		}
+	case familyIPv6:
+		if !addr.Is6() {
+			return fmt.Errorf("[%w] %s is not an IPv6 address", ErrInvalid, addr)
+		}
+	}
+
+	return nil
+}
+
+// netipValue is a net/netip value, addr returns the address checked against
+// the family.
+type netipValue[T comparable] struct {
+	p        *T
+	family   *addrFamily
+	parse    func(s string) (T, error)
+	addr     func(v T) netip.Addr
+	typeName string
+}
+
+func (n *netipValue[T]) Set(val string) error {
+	if val == "" {
+		var zero T
+		*n.p = zero
+
+		return nil
+	}
+
+	v, err := n.parse(val)
+	if err != nil {
+		return fmt.Errorf("[%w] %w", ErrInvalid, err)
+	}
+	*n.p = v
+
+	return nil
+}
+
+func (n *netipValue[T]) Get() any { return *n.p }
+
+func (n *netipValue[T]) Type() string { return n.typeName }
+
+func (n *netipValue[T]) String() string {
+	if n.IsEmpty() {
+		return ""
+	}
+
+	return fmt.Sprint(*n.p)
+}
+
+func (n *netipValue[T]) IsEmpty() bool {
+	var zero T
+
+	return *n.p == zero
+}
+
+func (n *netipValue[T]) Validate() error {
+	if n.IsEmpty() {
+		return fmt.Errorf("[%w] empty %s", ErrInvalid, n.typeName)
+	}
+
+	return n.family.check(n.addr(*n.p))
+}
+
+func (n *netipValue[T]) stage() Value {
+	staged := *n
+	staged.p = new(T)
+
+	return &staged
+}
+
+func (n *netipValue[T]) Restore(v any) error {
+	val, ok := v.(T)
+	if !ok {
+		return fmt.Errorf("[%w] can not restore %T as %s", ErrInvalid, v, reflect.TypeFor[T]())
+	}
+	*n.p = val
+
+	return nil
+}
+
+func (n *netipValue[T]) addrFamily() *addrFamily { return n.family }
+
+func newIPValue(val netip.Addr, p *netip.Addr, family *addrFamily) *netipValue[netip.Addr] {
+	*p = val
+
+	return &netipValue[netip.Addr]{
+		p:        p,
+		family:   family,
+		parse:    netip.ParseAddr,
+		addr:     func(v netip.Addr) netip.Addr { return v },
+		typeName: "ip",
+	}
+}
+
+func newIPPrefixValue(val netip.Prefix, p *netip.Prefix, family *addrFamily) *netipValue[netip.Prefix] {
+	*p = val
+
+	return &netipValue[netip.Prefix]{
+		p:        p,
+		family:   family,
+		parse:    netip.ParsePrefix,
+		addr:     netip.Prefix.Addr,
+		typeName: "ipprefix",
+	}
+}
+
+func newAddrPortValue(val netip.AddrPort, p *netip.AddrPort, family *addrFamily) *netipValue[netip.AddrPort] {
+	*p = val
+
+	return &netipValue[netip.AddrPort]{
+		p:        p,
+		family:   family,
+		parse:    netip.ParseAddrPort,
+		addr:     netip.AddrPort.Addr,
+		typeName: "addrport",
+	}
+}
+
+// familySliceValue is a slice whose elements share an address family.
+type familySliceValue[T any] struct {
+	*sliceValue[T]
+
+	family *addrFamily
+}
+
+func (s *familySliceValue[T]) addrFamily() *addrFamily { return s.family }
+
+// newFamilySliceValue creates a slice of the values newElem creates, they
+// share the family of the slice.
+func newFamilySliceValue[T any](val []T, p *[]T, newElem func(p *T, family *addrFamily) Value) *familySliceValue[T] {
+	family := new(addrFamily)
+
+	return &familySliceValue[T]{
+		sliceValue: newSliceValue(val, p, func(p *T) Value { return newElem(p, family) }),
+		family:     family,
+	}
+}
+
+func newIPSliceValue(val []netip.Addr, p *[]netip.Addr) *familySliceValue[netip.Addr] {
+	return newFamilySliceValue(val, p, func(p *netip.Addr, family *addrFamily) Value {
+		return newIPValue(*p, p, family)
+	})
+}
+
+func newIPPrefixSliceValue(val []netip.Prefix, p *[]netip.Prefix) *familySliceValue[netip.Prefix] {
+	return newFamilySliceValue(val, p, func(p *netip.Prefix, family *addrFamily) Value {
+		return newIPPrefixValue(*p, p, family)
+	})
+}
+
+func newAddrPortSliceValue(val []netip.AddrPort, p *[]netip.AddrPort) *familySliceValue[netip.AddrPort] {
+	return newFamilySliceValue(val, p, func(p *netip.AddrPort, family *addrFamily) Value {
+		return newAddrPortValue(*p, p, family)
+	})
+}
+
+// IP sets environment variable and returns the pointer of value.
+func IP(name string, value netip.Addr, opts ...Option) *netip.Addr {
+	return environmentVariableSetInstance.IP(name, value, opts...)
+}
+
+// IPPrefix sets environment variable and returns the pointer of value.
+func IPPrefix(name string, value netip.Prefix, opts ...Option) *netip.Prefix {
+	return environmentVariableSetInstance.IPPrefix(name, value, opts...)
+}
+
+// AddrPort sets environment variable and returns the pointer of value.
+func AddrPort(name string, value netip.AddrPort, opts ...Option) *netip.AddrPort {
+	return environmentVariableSetInstance.AddrPort(name, value, opts...)
+}
+
+// IPSlice sets environment variable and returns the pointer of value.
+func IPSlice(name string, value []netip.Addr, opts ...Option) *[]netip.Addr {
+	return environmentVariableSetInstance.IPSlice(name, value, opts...)
+}
+
+// IPPrefixSlice sets environment variable and returns the pointer of value.
+func IPPrefixSlice(name string, value []netip.Prefix, opts ...Option) *[]netip.Prefix {
+	return environmentVariableSetInstance.IPPrefixSlice(name, value, opts...)
+}
+
+// AddrPortSlice sets environment variable and returns the pointer of value.
+func AddrPortSlice(name string, value []netip.AddrPort, opts ...Option) *[]netip.AddrPort {
+	return environmentVariableSetInstance.AddrPortSlice(name, value, opts...)
+}
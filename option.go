@@ -0,0 +1,53 @@
+package getenv
+
+// Option configures an EnvironmentVariable while it is registered.
+type Option func(*EnvironmentVariable)
+
+// Required marks the environment variable as required. Parse returns
+// ErrEnvironmentVariableNotFound if it is not set, even if it has a default.
+func Required() Option {
+	return func(e *EnvironmentVariable) {
+		e.Required = true
+	}
+}
+
+// Description sets the description of the environment variable, it is shown
+// in usage output.
+func Description(text string) Option {
+	return func(e *EnvironmentVariable) {
+		e.Description = text
+	}
+}
+
+// Sensitive marks the environment variable as sensitive, its value is
+// redacted in errors, usage output, String and LogValue.
+func Sensitive() Option {
+	return func(e *EnvironmentVariable) {
+		e.Sensitive = true
+	}
+}
+
+// OnChange adds a callback which is called with the previous and current
+// value when Reload changes the value of the environment variable.
+func OnChange(fn func(previous, current any)) Option {
+	return func(e *EnvironmentVariable) {
+		e.onChange = append(e.onChange, fn)
+	}
+}
+
+// Shared allows the environment variable to be registered more than once,
+// every registration made with Shared receives the value.
+func Shared() Option {
+	return func(e *EnvironmentVariable) {
+		e.shareable = true
+	}
+}
+
+// AllowEmpty allows the environment variable to be empty. Parse does not
+// report ErrEnvironmentVariableIsEmpty for it, and a value explicitly set to
+// empty replaces the default of values implementing Emptier.
+func AllowEmpty() Option {
+	return func(e *EnvironmentVariable) {
+		e.allowEmpty = true
+	}
+}
@@ -0,0 +1,126 @@
+package getenv
+
+import "fmt"
+
+// compile time proofs.
+var (
+	_ Source       = NamedSource{}
+	_ Refresher    = NamedSource{}
+	_ originSource = NamedSource{}
+	_ originSource = MultiSource(nil)
+	_ originSource = DirSource("")
+)
+
+// Origin tells where the value of a variable came from, it is the name of
+// the NamedSource for values found in one.
+type Origin string
+
+// origins of values.
+const (
+	OriginDefault     Origin = "default"     // not set, the default is used
+	OriginEnvironment Origin = "environment" // found in the source
+	OriginFile        Origin = "file"        // read from a file, see SetFileSuffix and DirSource
+)
+
+// originSource is implemented by sources which know the origin of their
+// values.
+type originSource interface {
+	lookupOrigin(name string) (string, Origin, bool)
+}
+
+// lookupOrigin looks up name in source, values of sources which don't know
+// their origin come from the environment.
+func lookupOrigin(source Source, name string) (string, Origin, bool) {
+	if s, ok := source.(originSource); ok {
+		return s.lookupOrigin(name)
+	}
+
+	val, ok := source.Lookup(name)
+
+	return val, OriginEnvironment, ok
+}
+
+// NamedSource names a source, the values found in it have Name as their
+// origin:
+//
+//	getenv.SetSource(getenv.MultiSource{
+//		getenv.OSSource{},
+//		getenv.NamedSource{Name: ".env", Source: dotenv},
+//	})
+type NamedSource struct {
+	Source Source
+	Name   string
+}
+
+// Lookup returns the value of name from the source.
+func (n NamedSource) Lookup(name string) (string, bool) { return n.Source.Lookup(name) }
+
+func (n NamedSource) lookupOrigin(name string) (string, Origin, bool) {
+	val, ok := n.Source.Lookup(name)
+
+	return val, Origin(n.Name), ok
+}
+
+// Refresh refreshes the source if it is a Refresher.
+func (n NamedSource) Refresh() error {
+	if r, ok := n.Source.(Refresher); ok {
+		if err := r.Refresh(); err != nil {
+			return fmt.Errorf("%q %w", n.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m MultiSource) lookupOrigin(name string) (string, Origin, bool) {
+	for _, source := range m {
+		if val, origin, ok := lookupOrigin(source, name); ok {
+			return val, origin, true
+		}
+	}
+
+	return "", "", false
+}
+
+func (d DirSource) lookupOrigin(name string) (string, Origin, bool) {
+	val, ok := d.Lookup(name)
+
+	return val, OriginFile, ok
+}
+
+// Origin returns where the value came from as of the last Parse or Reload,
+// OriginDefault if the source didn't set it. Like the value, read it after
+// Parse returns.
+func (e *EnvironmentVariable) Origin() Origin {
+	if e.origin == "" {
+		return OriginDefault
+	}
+
+	return e.origin
+}
+
+// IsSet reports whether the variable name was found in the source by the
+// last Parse or Reload, even if it is empty. Constraints count an empty
+// value as set only if the variable allows empty, see Requires.
+func (e *EnvironmentVariableSet) IsSet(name string) bool {
+	return e.PresenceOf(name) != PresenceNotSet
+}
+
+// Lookup returns the variable registered as name, nil if there is none.
+// Name is resolved with the prefix like Var.
+func (e *EnvironmentVariableSet) Lookup(name string) *EnvironmentVariable {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.variables[e.resolveName(name)]
+}
+
+// IsSet reports whether the variable name was found by the global set.
+func IsSet(name string) bool {
+	return environmentVariableSetInstance.IsSet(name)
+}
+
+// Lookup returns the variable registered as name in the global set.
+func Lookup(name string) *EnvironmentVariable {
+	return environmentVariableSetInstance.Lookup(name)
+}
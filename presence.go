@@ -0,0 +1,58 @@
+package getenv
+
+// Presence tells how a variable was found by the last Parse.
+type Presence int
+
+// presence states.
+const (
+	PresenceNotSet Presence = iota // not found in the source
+	PresenceEmpty                  // found with an empty value
+	PresenceValue                  // found with a non empty value
+)
+
+func (p Presence) String() string {
+	switch p {
+	case PresenceEmpty:
+		return "empty"
+	case PresenceValue:
+		return "value"
+	default:
+		return "not set"
+	}
+}
+
+func presenceOf(val string) Presence {
+	if val == "" {
+		return PresenceEmpty
+	}
+
+	return PresenceValue
+}
+
+// PresenceOf returns the presence of the variable name in the source as of
+// the last Parse or Reload, name is resolved with the prefix like Var.
+func (e *EnvironmentVariableSet) PresenceOf(name string) Presence {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.actual[e.resolveName(name)]
+}
+
+// SetAllowEmpty sets the default of AllowEmpty for every variable of the
+// set.
+func (e *EnvironmentVariableSet) SetAllowEmpty(allow bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.allowEmpty = allow
+}
+
+// PresenceOf returns the presence of the variable name in the global set.
+func PresenceOf(name string) Presence {
+	return environmentVariableSetInstance.PresenceOf(name)
+}
+
+// SetAllowEmpty sets the default of AllowEmpty for the global set.
+func SetAllowEmpty(allow bool) {
+	environmentVariableSetInstance.SetAllowEmpty(allow)
+}
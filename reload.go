@@ -0,0 +1,379 @@
+package getenv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// compile time proofs.
+var (
+	_ Source    = DirSource("")
+	_ Source    = (*DotEnvFile)(nil)
+	_ Refresher = (*DotEnvFile)(nil)
+	_ Refresher = MultiSource(nil)
+)
+
+// Refresher is implemented by sources which cache their values, Reload calls
+// Refresh before reading them.
+type Refresher interface {
+	Refresh() error
+}
+
+// Restorer is implemented by values which can be set back to a result of
+// their Get method, Reload uses it to restore defaults and to roll back a
+// failed reload. Values which are pointers of the type Get returns, like
+// the built-in ones, don't need to implement it, other values which don't
+// implement it keep their value on Reload.
+type Restorer interface {
+	Restore(v any) error
+}
+
+// DirSource looks up a directory which has a file per variable, like
+// mounted Kubernetes secrets. Files are read on every lookup, the trailing
+// newline is trimmed.
+type DirSource string
+
+// Lookup reads the file named name in the directory.
+func (d DirSource) Lookup(name string) (string, bool) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", false
+	}
+
+	val, err := readValueFile(filepath.Join(string(d), name), DefaultMaxFileSize)
+	if err != nil {
+		return "", false
+	}
+
+	return val, true
+}
+
+// DotEnvFile is a .env file source which is read again on Refresh if the
+// file has changed.
+type DotEnvFile struct {
+	modTime time.Time
+	values  MapSource
+	path    string
+	size    int64
+	mu      sync.RWMutex
+}
+
+// NewDotEnvFile reads the .env file at path, see ParseDotEnv.
+func NewDotEnvFile(path string) (*DotEnvFile, error) {
+	f := &DotEnvFile{path: path}
+	if err := f.Refresh(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Lookup returns the value of name in the file.
+func (f *DotEnvFile) Lookup(name string) (string, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.values.Lookup(name)
+}
+
+// Refresh reads the file again if its modification time or size has
+// changed. Values are kept if the file can not be read or parsed.
+func (f *DotEnvFile) Refresh() error {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	f.mu.RLock()
+	unchanged := f.values != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size
+	f.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	values, err := ReadDotEnvFile(f.path)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.values = values
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+
+	return nil
+}
+
+// Refresh refreshes every layer implementing Refresher.
+func (m MultiSource) Refresh() error {
+	var errs []error
+
+	for _, source := range m {
+		if r, ok := source.(Refresher); ok {
+			if err := r.Refresh(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Value returns the current value of the variable registered as name, nil
+// if there is no such variable. It is safe to call while Reload runs, see
+// ValueIn for a typed variant.
+func (e *EnvironmentVariableSet) Value(name string) any {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	envVar, ok := e.variables[e.resolveName(name)]
+	if !ok {
+		return nil
+	}
+
+	return envVar.Value.Get()
+}
+
+// ValueIn returns the current value of the variable registered as name in
+// the set e, false if there is no such variable or its value is not a T.
+// Like Value, it is safe to call while Reload runs:
+//
+//	level, _ := getenv.ValueIn[int](set, "LOG_LEVEL")
+func ValueIn[T any](e *EnvironmentVariableSet, name string) (T, bool) {
+	v, ok := e.Value(name).(T)
+
+	return v, ok
+}
+
+// ValueOf returns the current value of the variable registered as name in
+// the global set, see ValueIn.
+func ValueOf[T any](name string) (T, bool) {
+	return ValueIn[T](environmentVariableSetInstance, name)
+}
+
+// Reload refreshes the source and parses every variable again, starting
+// from its default. Values are parsed into staging storage and swapped in
+// only if the whole set is valid, otherwise the error is returned and the
+// values are kept, regardless of the error handling mode. OnChange callbacks
+// of the changed variables are called in registration order after the new
+// values are swapped in. Readers should use Value or ValueIn, reading
+// pointers while Reload runs is a data race.
+func (e *EnvironmentVariableSet) Reload() error {
+	if r, ok := e.Source().(Refresher); ok {
+		if err := r.Refresh(); err != nil {
+			return fmt.Errorf("[%w] refresh %w", ErrInvalid, err)
+		}
+	}
+
+	changes, err := e.reload()
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		for _, fn := range change.envVar.onChange {
+			fn(change.previous, change.current)
+		}
+	}
+
+	return nil
+}
+
+type valueChange struct {
+	envVar   *EnvironmentVariable
+	previous any
+	current  any
+}
+
+// stager is implemented by values which keep their value behind a pointer,
+// stage returns a copy of the value backed by storage of its own.
+type stager interface {
+	stage() Value
+}
+
+// stageValue returns a copy of v backed by storage of its own, false if v
+// can not be copied.
+func stageValue(v Value) (Value, bool) {
+	if s, ok := v.(stager); ok {
+		return s.stage(), true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() == reflect.Struct {
+		return nil, false
+	}
+
+	staged, ok := reflect.New(rv.Elem().Type()).Interface().(Value)
+
+	return staged, ok
+}
+
+// reload parses staged copies of the registrations and writes the changed
+// values if the whole set is valid. Values which can not be staged, like custom
+// struct values, are parsed in place and rolled back on failure, values which
+// can not be restored either are skipped and keep their value.
+func (e *EnvironmentVariableSet) reload() ([]valueChange, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	registrations := e.registrations()
+
+	previous := make([]any, len(registrations))
+	written := make([]bool, len(registrations))
+	staged := make([]*EnvironmentVariable, len(registrations))
+	parsed := make([]*EnvironmentVariable, 0, len(registrations))
+
+	actual := e.actual
+	rollback := func() {
+		for i, envVar := range registrations {
+			if written[i] {
+				_ = restoreValue(envVar.Value, previous[i])
+			}
+		}
+		e.actual = actual
+	}
+
+	for i, envVar := range registrations {
+		previous[i] = envVar.Value.Get()
+
+		value, ok := stageValue(envVar.Value)
+		if !ok {
+			value = envVar.Value
+			if restoreValue(value, previous[i]) != nil {
+				continue
+			}
+			written[i] = true
+		}
+
+		if err := restoreValue(value, envVar.defaultValue); err != nil {
+			rollback()
+
+			return nil, fmt.Errorf("%q %w", envVar.Name, err)
+		}
+
+		stagedVar := *envVar
+		stagedVar.Value = value
+		staged[i] = &stagedVar
+		parsed = append(parsed, staged[i])
+	}
+
+	if err := e.parseVariables(parsed); err != nil {
+		rollback()
+
+		return nil, err
+	}
+
+	var changes []valueChange
+
+	for i, envVar := range registrations {
+		if staged[i] == nil {
+			if presence, ok := actual[envVar.Name]; ok {
+				e.actual[envVar.Name] = presence
+			}
+
+			continue
+		}
+
+		current := staged[i].Value.Get()
+		if reflect.DeepEqual(previous[i], current) {
+			continue
+		}
+
+		if !written[i] {
+			if err := restoreValue(envVar.Value, current); err != nil {
+				rollback()
+
+				return nil, fmt.Errorf("%q %w", envVar.Name, err)
+			}
+			written[i] = true
+		}
+		changes = append(changes, valueChange{envVar: envVar, previous: previous[i], current: current})
+	}
+
+	for i, envVar := range registrations {
+		if staged[i] != nil {
+			envVar.origin = staged[i].origin
+		}
+	}
+
+	return changes, nil
+}
+
+// restoreValue sets v back to val, a result of v.Get.
+func restoreValue(v Value, val any) error {
+	if r, ok := v.(Restorer); ok {
+		return r.Restore(val) //nolint:wrapcheck // Restore reports its own error.
+	}
+
+	rv := reflect.ValueOf(v)
+	in := reflect.ValueOf(val)
+
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || !in.IsValid() {
+		return fmt.Errorf("[%w] %T can not be restored", ErrInvalid, v)
+	}
+
+	elem := rv.Elem()
+	if in.Kind() != elem.Kind() || !in.Type().ConvertibleTo(elem.Type()) {
+		return fmt.Errorf("[%w] %T can not be restored", ErrInvalid, v)
+	}
+
+	elem.Set(in.Convert(elem.Type()))
+
+	return nil
+}
+
+// Watch calls Reload every interval and whenever the process receives
+// SIGHUP, until ctx is done. Zero interval disables polling. Reload errors
+// are passed to onError if it is not nil.
+func (e *EnvironmentVariableSet) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-hup:
+		}
+
+		if err := e.Reload(); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// Reload reloads the global set.
+func Reload() error {
+	if err := environmentVariableSetInstance.Reload(); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// Watch watches the global set, see EnvironmentVariableSet.Watch.
+func Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	environmentVariableSetInstance.Watch(ctx, interval, onError)
+}
@@ -0,0 +1,288 @@
+package getenv_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vigo/getenv"
+)
+
+func writeDotEnv(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	now := time.Now()
+	writeDotEnv(t, path, "LOG_LEVEL=debug\nPORT=9000\n", now)
+
+	dotenv, err := getenv.NewDotEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := getenv.NewEnvironmentVariableSet("reload", getenv.ContinueOnError)
+	set.SetSource(getenv.MultiSource{dotenv})
+
+	type change struct {
+		previous any
+		current  any
+	}
+	var changes []change
+
+	levels := map[string]int{"DEBUG": 0, "INFO": 1, "WARN": 2}
+	_ = set.LogLevel("LOG_LEVEL", levels, 1, getenv.OnChange(func(previous, current any) {
+		changes = append(changes, change{previous: previous, current: current})
+	}))
+	_ = set.Int("PORT", 8000)
+
+	if err = set.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	writeDotEnv(t, path, "LOG_LEVEL=warn\nPORT=invalid\n", now.Add(time.Second))
+	if err = set.Reload(); !errors.Is(err, getenv.ErrInvalid) {
+		t.Errorf("want [%v], got: [%v]", getenv.ErrInvalid, err)
+	}
+	if set.Value("LOG_LEVEL") != 0 || set.Value("PORT") != 9000 || len(changes) != 0 {
+		t.Errorf("failed reload should keep values, got: [%v] [%v] %v", set.Value("LOG_LEVEL"), set.Value("PORT"), changes)
+	}
+
+	writeDotEnv(t, path, "LOG_LEVEL=warn\n", now.Add(2*time.Second))
+	if err = set.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if set.Value("LOG_LEVEL") != 2 {
+		t.Errorf("want [2], got: [%v]", set.Value("LOG_LEVEL"))
+	}
+	if set.Value("PORT") != 8000 {
+		t.Errorf("removed variable should have default, got: [%v]", set.Value("PORT"))
+	}
+	if len(changes) != 1 || changes[0].previous != 0 || changes[0].current != 2 {
+		t.Errorf("want one change from 0 to 2, got: %v", changes)
+	}
+	if set.Value("NON_EXISTING") != nil {
+		t.Errorf("want nil, got: [%v]", set.Value("NON_EXISTING"))
+	}
+}
+
+func TestReloadConcurrentReads(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "TIMEOUT"), []byte("1s\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	set := getenv.NewEnvironmentVariableSet("concurrent", getenv.ContinueOnError)
+	set.SetSource(getenv.DirSource(dir))
+	_ = set.Duration("TIMEOUT", time.Minute)
+	if err := set.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Go(func() {
+			for range 100 {
+				if v, ok := set.Value("TIMEOUT").(time.Duration); !ok || v != time.Second {
+					t.Errorf("want [1s], got: [%v]", v)
+				}
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg.Go(func() {
+		set.Watch(ctx, time.Millisecond, func(err error) {
+			t.Errorf("unexpected error: %v", err)
+		})
+	})
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	wg.Wait()
+}
+
+func TestReloadBuiltinValues(t *testing.T) {
+	source := getenv.MapSource{
+		"BOOL": "false", "INT": "1", "INT64": "1", "FLOAT64": "1.5", "STRING": "a",
+		"DURATION": "1s", "TCP": ":1", "UDP": "127.0.0.1:1", "UNIX": "/a.sock", "SECRET": "a",
+		"URL": "http://a", "IP": "::1", "PREFIX": "10.0.0.0/8", "ADDRPORT": "[::1]:1", "LOG_LEVEL": "DEBUG",
+		"STRINGS": "a", "INTS": "1", "INT64S": "1", "FLOAT64S": "1", "DURATIONS": "1s", "TCPS": ":1",
+		"UDPS": ":1", "UNIXS": "/a", "IPS": "::1", "PREFIXES": "::/8", "ADDRPORTS": "[::1]:1",
+		"STRING_MAP": "a=1", "INT_MAP": "a=1", "DURATION_MAP": "a=1s",
+	}
+	reloaded := getenv.MapSource{
+		"BOOL": "true", "INT": "2", "INT64": "2", "FLOAT64": "2.5", "STRING": "b",
+		"DURATION": "2s", "TCP": ":2", "UDP": "127.0.0.1:2", "UNIX": "/b.sock", "SECRET": "b",
+		"URL": "http://b", "IP": "::2", "PREFIX": "10.0.0.0/16", "ADDRPORT": "[::1]:2", "LOG_LEVEL": "INFO",
+		"STRINGS": "a,b", "INTS": "1,2", "INT64S": "1,2", "FLOAT64S": "1,2", "DURATIONS": "1s,2s", "TCPS": ":1,:2",
+		"UDPS": ":1,:2", "UNIXS": "/a,/b", "IPS": "::1,::2", "PREFIXES": "::/8,::/16", "ADDRPORTS": "[::1]:1,[::1]:2",
+		"STRING_MAP": "a=2", "INT_MAP": "a=2", "DURATION_MAP": "a=2s",
+	}
+
+	set := getenv.NewEnvironmentVariableSet("reload", getenv.ContinueOnError)
+	set.SetSource(source)
+	_ = set.Bool("BOOL", false)
+	_ = set.Int("INT", 0)
+	_ = set.Int64("INT64", 0)
+	_ = set.Float64("FLOAT64", 0)
+	_ = set.String("STRING", "")
+	_ = set.Duration("DURATION", 0)
+	_ = set.TCPAddr("TCP", "")
+	_ = set.UDPAddr("UDP", "")
+	_ = set.UnixAddr("UNIX", "")
+	_ = set.Secret("SECRET", "")
+	_ = set.URL("URL", "")
+	_ = set.IP("IP", netip.Addr{})
+	_ = set.IPPrefix("PREFIX", netip.Prefix{})
+	_ = set.AddrPort("ADDRPORT", netip.AddrPort{})
+	_ = set.LogLevel("LOG_LEVEL", map[string]int{"DEBUG": 0, "INFO": 1}, 0)
+	_ = set.StringSlice("STRINGS", nil)
+	_ = set.IntSlice("INTS", nil)
+	_ = set.Int64Slice("INT64S", nil)
+	_ = set.Float64Slice("FLOAT64S", nil)
+	_ = set.DurationSlice("DURATIONS", nil)
+	_ = set.TCPAddrSlice("TCPS", nil)
+	_ = set.UDPAddrSlice("UDPS", nil)
+	_ = set.UnixAddrSlice("UNIXS", nil)
+	_ = set.IPSlice("IPS", nil)
+	_ = set.IPPrefixSlice("PREFIXES", nil)
+	_ = set.AddrPortSlice("ADDRPORTS", nil)
+	_ = set.StringMap("STRING_MAP", nil)
+	_ = set.IntMap("INT_MAP", nil)
+	_ = set.DurationMap("DURATION_MAP", nil)
+
+	if err := set.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	initial := make(map[string]any, len(source))
+	for name := range source {
+		initial[name] = set.Value(name)
+	}
+	maps.Copy(source, reloaded)
+
+	if err := set.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name := range source {
+		if reflect.DeepEqual(initial[name], set.Value(name)) {
+			t.Errorf("%s want reloaded value, got: [%v]", name, set.Value(name))
+		}
+	}
+}
+
+func TestReloadStagesValues(t *testing.T) {
+	values := map[string]string{"TEST_STAGE_LEVEL": "2", "TEST_STAGE_PORT": "9000"}
+
+	var level *int
+
+	var observed []int
+
+	set := getenv.NewEnvironmentVariableSet("stage", getenv.ContinueOnError)
+	set.SetSource(getenv.SourceFunc(func(name string) (string, bool) {
+		if name == "TEST_STAGE_PORT" {
+			observed = append(observed, *level)
+		}
+		val, ok := values[name]
+
+		return val, ok
+	}))
+	level = set.Int("TEST_STAGE_LEVEL", 1)
+	port := set.Int("TEST_STAGE_PORT", 8000)
+
+	if err := set.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	observed = nil
+	values["TEST_STAGE_LEVEL"], values["TEST_STAGE_PORT"] = "3", "invalid"
+	if err := set.Reload(); !errors.Is(err, getenv.ErrInvalid) {
+		t.Errorf("want [%v], got: [%v]", getenv.ErrInvalid, err)
+	}
+	if *level != 2 || *port != 9000 {
+		t.Errorf("failed reload should keep values, got: [%d] [%d]", *level, *port)
+	}
+
+	values["TEST_STAGE_PORT"] = "9001"
+	if err := set.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *level != 3 || *port != 9001 {
+		t.Errorf("want [3] [9001], got: [%d] [%d]", *level, *port)
+	}
+
+	for _, v := range observed {
+		if v != 2 {
+			t.Errorf("live value should not change while parsing, got: %v", observed)
+
+			break
+		}
+	}
+
+	if v, ok := getenv.ValueIn[int](set, "TEST_STAGE_LEVEL"); !ok || v != 3 {
+		t.Errorf("want [3 true], got: [%v %v]", v, ok)
+	}
+	if _, ok := getenv.ValueIn[string](set, "TEST_STAGE_LEVEL"); ok {
+		t.Error("want [false], got: [true]")
+	}
+}
+
+type csvValue struct {
+	items []string
+}
+
+func (c *csvValue) Set(s string) error {
+	c.items = strings.Split(s, ",")
+
+	return nil
+}
+
+func (c *csvValue) Get() any { return c.items }
+
+func TestReloadSkipsUnrestorableValues(t *testing.T) {
+	source := getenv.MapSource{"TEST_SKIP_CSV": "a,b", "TEST_SKIP_PORT": "80"}
+	set := getenv.NewEnvironmentVariableSet("reload", getenv.ContinueOnError)
+	set.SetSource(source)
+
+	var csv csvValue
+	set.Var(&csv, "TEST_SKIP_CSV")
+	port := set.Int("TEST_SKIP_PORT", 0)
+
+	if err := set.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	source["TEST_SKIP_CSV"] = "c"
+	source["TEST_SKIP_PORT"] = "443"
+	if err := set.Reload(); err != nil {
+		t.Fatalf("want [nil], got: [%v]", err)
+	}
+	if *port != 443 {
+		t.Errorf("want [443], got: [%d]", *port)
+	}
+	if !reflect.DeepEqual(csv.items, []string{"a", "b"}) {
+		t.Errorf("want [[a b]], got: [%v]", csv.items)
+	}
+	if !set.IsSet("TEST_SKIP_CSV") {
+		t.Error("want TEST_SKIP_CSV to be set")
+	}
+}
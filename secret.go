@@ -0,0 +1,88 @@
+package getenv
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// redacted replaces sensitive values wherever they are rendered.
+const redacted = "******"
+
+// compile time proofs.
+var (
+	_ fmt.Formatter  = SecretString("")
+	_ fmt.Stringer   = SecretString("")
+	_ slog.LogValuer = SecretString("")
+)
+
+// SecretString holds a sensitive string. It renders as "******" with fmt
+// and slog, use Reveal to access the plaintext.
+type SecretString string
+
+// Reveal returns the plaintext.
+func (s SecretString) Reveal() string { return string(s) }
+
+func (s SecretString) String() string { return redacted }
+
+// Format renders the redacted value for every verb.
+func (s SecretString) Format(f fmt.State, _ rune) { _, _ = fmt.Fprint(f, redacted) }
+
+// LogValue implements slog.LogValuer.
+func (s SecretString) LogValue() slog.Value { return slog.StringValue(redacted) }
+
+// sensitiveValue is implemented by values which are always sensitive, Var
+// marks their variables as sensitive however they are registered.
+type sensitiveValue interface {
+	sensitive() bool
+}
+
+type secretValue SecretString
+
+func newSecretValue(val string, p *SecretString) *secretValue {
+	*p = SecretString(val)
+
+	return (*secretValue)(p)
+}
+
+func (s *secretValue) Set(val string) error {
+	*s = secretValue(val)
+
+	return nil
+}
+
+func (s *secretValue) Get() any { return SecretString(*s) }
+
+func (s *secretValue) Type() string { return "secret" }
+
+func (s *secretValue) IsEmpty() bool { return *s == "" }
+
+func (s *secretValue) String() string { return redacted }
+
+func (s *secretValue) sensitive() bool { return true }
+
+// Secret sets environment variable and returns the pointer of value.
+func Secret(name string, value string, opts ...Option) *SecretString {
+	return environmentVariableSetInstance.Secret(name, value, opts...)
+}
+
+// redactedError hides the message of an error of a sensitive value, which
+// may contain the value, and keeps the error for errors.Is and errors.As.
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	if errors.Is(e.err, ErrInvalid) {
+		return "[" + ErrInvalid.Error() + "] " + redacted
+	}
+
+	return redacted
+}
+
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactError replaces the message of every error of a sensitive value.
+func redactError(err error) error {
+	return &redactedError{err: err}
+}
@@ -0,0 +1,135 @@
+package getenv
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// sliceValue is a list of values, each element is parsed by the Value of
+// its scalar type.
+type sliceValue[T any] struct {
+	p       *[]T
+	newElem func(p *T) Value
+	list    listConfig
+}
+
+func newSliceValue[T any](val []T, p *[]T, newElem func(p *T) Value) *sliceValue[T] {
+	*p = val
+
+	return &sliceValue[T]{p: p, newElem: newElem, list: newListConfig()}
+}
+
+func (s *sliceValue[T]) Set(val string) error {
+	elements, err := s.list.split(val)
+	if err != nil {
+		return err
+	}
+
+	result := make([]T, len(elements))
+	for i, element := range elements {
+		if element == "" {
+			return fmt.Errorf("[%w] element %d is empty", ErrInvalid, i)
+		}
+		if err = s.newElem(&result[i]).Set(element); err != nil {
+			return fmt.Errorf("element %d %q %w", i, element, err)
+		}
+	}
+
+	*s.p = result
+
+	return nil
+}
+
+func (s *sliceValue[T]) Get() any { return *s.p }
+
+func (s *sliceValue[T]) Type() string {
+	var zero T
+
+	return "[]" + typeName(s.newElem(&zero))
+}
+
+func (s *sliceValue[T]) IsEmpty() bool { return len(*s.p) == 0 }
+
+// Validate validates every element whose Value is a Validator, the default
+// included.
+func (s *sliceValue[T]) Validate() error {
+	for i := range *s.p {
+		elem := s.newElem(&(*s.p)[i])
+
+		v, ok := elem.(Validator)
+		if !ok {
+			return nil
+		}
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("element %d %q %w", i, fmt.Sprint(elem.Get()), err)
+		}
+	}
+
+	return nil
+}
+
+func (s *sliceValue[T]) stage() Value {
+	staged := *s
+	staged.p = new([]T)
+
+	return &staged
+}
+
+func (s *sliceValue[T]) Restore(v any) error {
+	val, ok := v.([]T)
+	if !ok {
+		return fmt.Errorf("[%w] can not restore %T as %s", ErrInvalid, v, reflect.TypeFor[[]T]())
+	}
+
+	*s.p = val
+
+	return nil
+}
+
+func (s *sliceValue[T]) listConfig() *listConfig { return &s.list }
+
+func newIntSliceValue(val []int, p *[]int) *sliceValue[int] {
+	return newSliceValue(val, p, func(p *int) Value { return (*intValue)(p) })
+}
+
+func newInt64SliceValue(val []int64, p *[]int64) *sliceValue[int64] {
+	return newSliceValue(val, p, func(p *int64) Value { return (*int64Value)(p) })
+}
+
+func newFloat64SliceValue(val []float64, p *[]float64) *sliceValue[float64] {
+	return newSliceValue(val, p, func(p *float64) Value { return (*float64Value)(p) })
+}
+
+func newDurationSliceValue(val []time.Duration, p *[]time.Duration) *sliceValue[time.Duration] {
+	return newSliceValue(val, p, func(p *time.Duration) Value { return (*durationValue)(p) })
+}
+
+func newTCPAddrSliceValue(val []string, p *[]string) *sliceValue[string] {
+	return newSliceValue(val, p, func(p *string) Value { return (*tcpAddrValue)(p) })
+}
+
+// IntSlice sets environment variable and returns the pointer of value.
+func IntSlice(name string, value []int, opts ...Option) *[]int {
+	return environmentVariableSetInstance.IntSlice(name, value, opts...)
+}
+
+// Int64Slice sets environment variable and returns the pointer of value.
+func Int64Slice(name string, value []int64, opts ...Option) *[]int64 {
+	return environmentVariableSetInstance.Int64Slice(name, value, opts...)
+}
+
+// Float64Slice sets environment variable and returns the pointer of value.
+func Float64Slice(name string, value []float64, opts ...Option) *[]float64 {
+	return environmentVariableSetInstance.Float64Slice(name, value, opts...)
+}
+
+// DurationSlice sets environment variable and returns the pointer of value.
+func DurationSlice(name string, value []time.Duration, opts ...Option) *[]time.Duration {
+	return environmentVariableSetInstance.DurationSlice(name, value, opts...)
+}
+
+// TCPAddrSlice sets environment variable and returns the pointer of value.
+func TCPAddrSlice(name string, value []string, opts ...Option) *[]string {
+	return environmentVariableSetInstance.TCPAddrSlice(name, value, opts...)
+}
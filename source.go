@@ -0,0 +1,76 @@
+package getenv
+
+import (
+	"os"
+	"strings"
+)
+
+// compile time proofs.
+var (
+	_ Source = OSSource{}
+	_ Source = MapSource(nil)
+	_ Source = MultiSource(nil)
+	_ Source = SourceFunc(nil)
+)
+
+// Source looks up environment variable values, the boolean reports whether
+// the variable is present.
+type Source interface {
+	Lookup(name string) (string, bool)
+}
+
+// SourceFunc is an adapter to use ordinary functions as Source.
+type SourceFunc func(name string) (string, bool)
+
+// Lookup calls f(name).
+func (f SourceFunc) Lookup(name string) (string, bool) { return f(name) }
+
+// OSSource looks up the process environment, it is the default source.
+type OSSource struct{}
+
+// Lookup calls os.LookupEnv.
+func (OSSource) Lookup(name string) (string, bool) { return os.LookupEnv(name) }
+
+// MapSource looks up a map.
+type MapSource map[string]string
+
+// Lookup returns the value of name in the map.
+func (m MapSource) Lookup(name string) (string, bool) {
+	val, ok := m[name]
+
+	return val, ok
+}
+
+// EnvironSource creates a MapSource from "KEY=value" formatted entries, as
+// returned by os.Environ. Later entries override earlier ones, entries
+// without "=" are ignored.
+func EnvironSource(environ []string) MapSource {
+	m := make(MapSource, len(environ))
+	for _, entry := range environ {
+		if key, val, ok := strings.Cut(entry, "="); ok {
+			m[key] = val
+		}
+	}
+
+	return m
+}
+
+// MultiSource layers several sources, they are looked up in order and the
+// first one having the variable wins.
+type MultiSource []Source
+
+// Lookup returns the value from the first source having the variable.
+func (m MultiSource) Lookup(name string) (string, bool) {
+	for _, source := range m {
+		if val, ok := source.Lookup(name); ok {
+			return val, true
+		}
+	}
+
+	return "", false
+}
+
+// SetSource sets the source of the global set, nil means OSSource.
+func SetSource(source Source) {
+	environmentVariableSetInstance.SetSource(source)
+}
@@ -16,7 +16,11 @@ func (s *stringValue) Set(val string) error {
 
 func (s *stringValue) Get() any { return string(*s) }
 
+func (s *stringValue) Type() string { return "string" }
+
+func (s *stringValue) IsEmpty() bool { return *s == "" }
+
 // String sets environment variable and returns the pointer of value.
-func String(name string, value string) *string {
-	return environmentVariableSetInstance.String(name, value)
+func String(name string, value string, opts ...Option) *string {
+	return environmentVariableSetInstance.String(name, value, opts...)
 }
@@ -1,34 +1,57 @@
 package getenv
 
-import "strings"
+import "fmt"
 
-type stringSliceValue []string
+type stringSliceValue struct {
+	p    *[]string
+	list listConfig
+}
 
 func newStringSliceValue(val []string, p *[]string) *stringSliceValue {
 	*p = val
 
-	return (*stringSliceValue)(p)
+	return &stringSliceValue{p: p, list: newListConfig()}
 }
 
 func (s *stringSliceValue) Set(val string) error {
-	parts := strings.Split(val, ",")
-	result := make([]string, 0, len(parts))
-
-	for _, part := range parts {
-		trimmed := strings.TrimSpace(part)
-		if trimmed != "" {
-			result = append(result, trimmed)
-		}
+	elements, err := s.list.split(val)
+	if err != nil {
+		return err
+	}
+
+	*s.p = elements
+
+	return nil
+}
+
+func (s *stringSliceValue) Get() any { return *s.p }
+
+func (s *stringSliceValue) Type() string { return "[]string" }
+
+func (s *stringSliceValue) IsEmpty() bool { return len(*s.p) == 0 }
+
+func (s *stringSliceValue) stage() Value {
+	staged := *s
+	staged.p = new([]string)
+
+	return &staged
+}
+
+func (s *stringSliceValue) Restore(v any) error {
+	val, ok := v.([]string)
+	if !ok {
+		return fmt.Errorf("[%w] can not restore %T as []string", ErrInvalid, v)
 	}
 
-	*s = result
+	*s.p = val
 
 	return nil
 }
 
-func (s *stringSliceValue) Get() any { return []string(*s) }
+func (s *stringSliceValue) listConfig() *listConfig { return &s.list }
 
 // StringSlice sets environment variable and returns the pointer of value.
-func StringSlice(name string, value []string) *[]string {
-	return environmentVariableSetInstance.StringSlice(name, value)
+// Use Separator and KeepEmpty to configure how the value is split.
+func StringSlice(name string, value []string, opts ...Option) *[]string {
+	return environmentVariableSetInstance.StringSlice(name, value, opts...)
 }
@@ -15,7 +15,9 @@ func newTCPAddrValue(val string, p *string) *tcpAddrValue {
 
 func (s *tcpAddrValue) Set(val string) error {
 	if val == "" {
-		return fmt.Errorf("[%w]", ErrEnvironmentVariableIsEmpty)
+		*s = ""
+
+		return nil
 	}
 
 	tcpAddr, err := ValidateTCPNetworkAddress(val)
@@ -30,9 +32,21 @@ func (s *tcpAddrValue) Set(val string) error {
 
 func (s *tcpAddrValue) Get() any { return string(*s) }
 
+func (s *tcpAddrValue) Type() string { return "tcpaddr" }
+
+func (s *tcpAddrValue) IsEmpty() bool { return *s == "" }
+
+func (s *tcpAddrValue) Validate() error {
+	if _, err := ValidateTCPNetworkAddress(string(*s)); err != nil {
+		return fmt.Errorf("[%w] %w", ErrInvalid, err)
+	}
+
+	return nil
+}
+
 // TCPAddr sets environment variable and returns the pointer of value.
-func TCPAddr(name string, value string) *string {
-	return environmentVariableSetInstance.TCPAddr(name, value)
+func TCPAddr(name string, value string, opts ...Option) *string {
+	return environmentVariableSetInstance.TCPAddr(name, value, opts...)
 }
 
 // ValidateTCPNetworkAddress validates given tcp address as string and
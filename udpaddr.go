@@ -0,0 +1,117 @@
+package getenv
+
+import (
+	"fmt"
+	"net"
+)
+
+type udpAddrValue struct {
+	p      *string
+	family *addrFamily
+}
+
+func newUDPAddrValue(val string, p *string, family *addrFamily) *udpAddrValue {
+	*p = val
+
+	return &udpAddrValue{p: p, family: family}
+}
+
+func (u *udpAddrValue) Set(val string) error {
+	if val == "" {
+		*u.p = ""
+
+		return nil
+	}
+
+	udpAddr, err := u.resolve(val)
+	if err != nil {
+		return err
+	}
+
+	*u.p = udpAddr.String()
+
+	return nil
+}
+
+func (u *udpAddrValue) Get() any { return *u.p }
+
+func (u *udpAddrValue) Type() string { return "udpaddr" }
+
+func (u *udpAddrValue) IsEmpty() bool { return *u.p == "" }
+
+func (u *udpAddrValue) Validate() error {
+	if u.IsEmpty() {
+		return fmt.Errorf("[%w] empty udp address", ErrInvalid)
+	}
+
+	_, err := u.resolve(*u.p)
+
+	return err
+}
+
+// resolve resolves addr with the network of the family, so a host name
+// resolves to an address of that family.
+func (u *udpAddrValue) resolve(addr string) (*net.UDPAddr, error) {
+	network := "udp"
+
+	switch *u.family {
+	case familyIPv4:
+		network = "udp4"
+	case familyIPv6:
+		network = "udp6"
+	}
+
+	udpAddr, err := ValidateUDPNetworkAddress(network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("[%w] %w", ErrInvalid, err)
+	}
+
+	return udpAddr, nil
+}
+
+func (u *udpAddrValue) stage() Value {
+	staged := *u
+	staged.p = new(string)
+
+	return &staged
+}
+
+func (u *udpAddrValue) Restore(v any) error {
+	val, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("[%w] can not restore %T as udp address", ErrInvalid, v)
+	}
+	*u.p = val
+
+	return nil
+}
+
+func (u *udpAddrValue) addrFamily() *addrFamily { return u.family }
+
+func newUDPAddrSliceValue(val []string, p *[]string) *familySliceValue[string] {
+	return newFamilySliceValue(val, p, func(p *string, family *addrFamily) Value {
+		return newUDPAddrValue(*p, p, family)
+	})
+}
+
+// UDPAddr sets environment variable and returns the pointer of value.
+func UDPAddr(name string, value string, opts ...Option) *string {
+	return environmentVariableSetInstance.UDPAddr(name, value, opts...)
+}
+
+// UDPAddrSlice sets environment variable and returns the pointer of value.
+func UDPAddrSlice(name string, value []string, opts ...Option) *[]string {
+	return environmentVariableSetInstance.UDPAddrSlice(name, value, opts...)
+}
+
+// ValidateUDPNetworkAddress validates given udp address as string and
+// returns an error if the provided arg is not a valid address of network,
+// which is "udp", "udp4" or "udp6".
+func ValidateUDPNetworkAddress(network, addr string) (*net.UDPAddr, error) {
+	udpAddr, err := net.ResolveUDPAddr(network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return udpAddr, nil
+}
@@ -0,0 +1,69 @@
+package getenv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxUnixSocketPathLength is the longest path fitting sun_path of Linux,
+// with the terminating NUL.
+const maxUnixSocketPathLength = 107
+
+type unixAddrValue string
+
+func newUnixAddrValue(val string, p *string) *unixAddrValue {
+	*p = val
+
+	return (*unixAddrValue)(p)
+}
+
+func (u *unixAddrValue) Set(val string) error {
+	*u = unixAddrValue(val)
+
+	return nil
+}
+
+func (u *unixAddrValue) Get() any { return string(*u) }
+
+func (u *unixAddrValue) Type() string { return "unixaddr" }
+
+func (u *unixAddrValue) IsEmpty() bool { return *u == "" }
+
+func (u *unixAddrValue) Validate() error {
+	if err := ValidateUnixSocketPath(string(*u)); err != nil {
+		return fmt.Errorf("[%w] %w", ErrInvalid, err)
+	}
+
+	return nil
+}
+
+func newUnixAddrSliceValue(val []string, p *[]string) *sliceValue[string] {
+	return newSliceValue(val, p, func(p *string) Value { return (*unixAddrValue)(p) })
+}
+
+// UnixAddr sets environment variable and returns the pointer of value.
+func UnixAddr(name string, value string, opts ...Option) *string {
+	return environmentVariableSetInstance.UnixAddr(name, value, opts...)
+}
+
+// UnixAddrSlice sets environment variable and returns the pointer of value.
+func UnixAddrSlice(name string, value []string, opts ...Option) *[]string {
+	return environmentVariableSetInstance.UnixAddrSlice(name, value, opts...)
+}
+
+// ValidateUnixSocketPath validates given unix socket path and returns an
+// error if it can not be used as a socket address. Paths starting with "@"
+// are Linux abstract sockets.
+func ValidateUnixSocketPath(path string) error {
+	switch {
+	case path == "":
+		return errors.New("unix socket path is empty")
+	case strings.ContainsRune(path, 0):
+		return errors.New("unix socket path has a NUL byte")
+	case len(path) > maxUnixSocketPathLength:
+		return fmt.Errorf("unix socket path exceeds %d bytes", maxUnixSocketPathLength)
+	}
+
+	return nil
+}
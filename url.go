@@ -0,0 +1,196 @@
+package getenv
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// userinfo policies of URL variables.
+const (
+	userinfoAllowed = iota
+	userinfoRequired
+	userinfoForbidden
+)
+
+// urlConfig holds the URL options.
+type urlConfig struct {
+	defaultPorts map[string]int
+	schemes      []string
+	userinfo     int
+	requireHost  bool
+}
+
+type urlValue struct {
+	p      *url.URL
+	config urlConfig
+}
+
+func newURLValue(val url.URL, p *url.URL) *urlValue {
+	*p = val
+
+	return &urlValue{p: p}
+}
+
+// Set parses val, the error doesn't have the URL to keep credentials out of
+// it.
+func (u *urlValue) Set(val string) error {
+	if val == "" {
+		*u.p = url.URL{}
+
+		return nil
+	}
+
+	parsed, err := parseURL(val)
+	if err != nil {
+		return err
+	}
+	u.applyDefaultPort(parsed)
+	*u.p = *parsed
+
+	return nil
+}
+
+func (u *urlValue) Get() any { return *u.p }
+
+func (u *urlValue) Type() string { return "url" }
+
+// String renders the URL with its password redacted.
+func (u *urlValue) String() string { return u.p.Redacted() }
+
+func (u *urlValue) IsEmpty() bool { return *u.p == url.URL{} }
+
+func (u *urlValue) Validate() error {
+	if len(u.config.schemes) > 0 && !slices.Contains(u.config.schemes, u.p.Scheme) {
+		return fmt.Errorf(
+			"[%w] scheme %q is not allowed, want one of %s", ErrInvalid, u.p.Scheme, strings.Join(u.config.schemes, ", "),
+		)
+	}
+	if u.config.requireHost && u.p.Host == "" {
+		return fmt.Errorf("[%w] host is required", ErrInvalid)
+	}
+
+	switch u.config.userinfo {
+	case userinfoRequired:
+		if u.p.User == nil {
+			return fmt.Errorf("[%w] userinfo is required", ErrInvalid)
+		}
+	case userinfoForbidden:
+		if u.p.User != nil {
+			return fmt.Errorf("[%w] userinfo is not allowed", ErrInvalid)
+		}
+	}
+
+	return nil
+}
+
+func (u *urlValue) stage() Value {
+	staged := *u
+	staged.p = new(url.URL)
+
+	return &staged
+}
+
+func (u *urlValue) Restore(v any) error {
+	val, ok := v.(url.URL)
+	if !ok {
+		return fmt.Errorf("[%w] can not restore %T as url.URL", ErrInvalid, v)
+	}
+
+	*u.p = val
+
+	return nil
+}
+
+func (u *urlValue) applyDefaultPort(parsed *url.URL) {
+	port, ok := u.config.defaultPorts[parsed.Scheme]
+	if !ok || parsed.Host == "" || parsed.Port() != "" {
+		return
+	}
+
+	parsed.Host = net.JoinHostPort(parsed.Hostname(), strconv.Itoa(port))
+}
+
+// parseURL parses raw, the error is stripped off the URL.
+func parseURL(raw string) (*url.URL, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		return nil, fmt.Errorf("[%w] %w", ErrInvalid, err)
+	}
+
+	return parsed, nil
+}
+
+// mustParseURL parses the default of URL variables, it panics if raw is
+// not a URL.
+func mustParseURL(raw string) url.URL {
+	if raw == "" {
+		return url.URL{}
+	}
+
+	parsed, err := parseURL(raw)
+	if err != nil {
+		panic(fmt.Sprintf("getenv: invalid default URL %v", err))
+	}
+
+	return *parsed
+}
+
+func urlOption(option string, fn func(u *urlValue)) Option {
+	return func(e *EnvironmentVariable) {
+		u, ok := e.Value.(*urlValue)
+		if !ok {
+			panic(fmt.Sprintf("getenv: %s option is not supported by %s", option, e.Name))
+		}
+		fn(u)
+	}
+}
+
+// AllowedSchemes limits the schemes of a URL variable. It panics if the
+// value is not a URL, like the other URL options.
+func AllowedSchemes(schemes ...string) Option {
+	return urlOption("AllowedSchemes", func(u *urlValue) {
+		u.config.schemes = append(u.config.schemes, schemes...)
+	})
+}
+
+// RequireHost requires the URL to have a host.
+func RequireHost() Option {
+	return urlOption("RequireHost", func(u *urlValue) { u.config.requireHost = true })
+}
+
+// RequireUserinfo requires the URL to have a user name.
+func RequireUserinfo() Option {
+	return urlOption("RequireUserinfo", func(u *urlValue) { u.config.userinfo = userinfoRequired })
+}
+
+// ForbidUserinfo rejects URLs which have a user name or a password.
+func ForbidUserinfo() Option {
+	return urlOption("ForbidUserinfo", func(u *urlValue) { u.config.userinfo = userinfoForbidden })
+}
+
+// DefaultPort adds port to the URLs of scheme which don't have one, the
+// default value included.
+func DefaultPort(scheme string, port int) Option {
+	return urlOption("DefaultPort", func(u *urlValue) {
+		if u.config.defaultPorts == nil {
+			u.config.defaultPorts = make(map[string]int)
+		}
+		u.config.defaultPorts[scheme] = port
+		u.applyDefaultPort(u.p)
+	})
+}
+
+// URL sets environment variable and returns the pointer of value.
+func URL(name string, value string, opts ...Option) *url.URL {
+	return environmentVariableSetInstance.URL(name, value, opts...)
+}
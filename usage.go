@@ -0,0 +1,101 @@
+package getenv
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Typer is implemented by values which name their type in usage output.
+type Typer interface {
+	Type() string
+}
+
+// AllowedValuer is implemented by values which accept only a fixed set of
+// values, they are listed in usage output.
+type AllowedValuer interface {
+	AllowedValues() []string
+}
+
+// PrintDefaults writes every registered variable in registration order, with
+// its type, description, default, current value, required flag and allowed
+// values to w.
+func (e *EnvironmentVariableSet) PrintDefaults(w io.Writer) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, envVar := range e.ordered {
+		fmt.Fprintf(w, "  %s %s\n", envVar.Name, typeName(envVar.Value))
+		if envVar.Description != "" {
+			fmt.Fprintf(w, "    \t%s\n", envVar.Description)
+		}
+
+		details := []string{
+			"default: " + envVar.DefValue,
+			"current: " + envVar.formatValue(),
+		}
+		if envVar.Required {
+			details = append(details, "required")
+		}
+		allowed := envVar.allowed
+		if v, ok := envVar.Value.(AllowedValuer); ok {
+			allowed = append(v.AllowedValues(), allowed...)
+		}
+		if len(allowed) > 0 {
+			details = append(details, "allowed: "+strings.Join(allowed, ", "))
+		}
+		fmt.Fprintf(w, "    \t(%s)\n", strings.Join(details, ", "))
+	}
+}
+
+// PrintUsage calls the Usage function of the set if it is set, otherwise
+// prints the name of the set and the defaults to Output.
+func (e *EnvironmentVariableSet) PrintUsage() {
+	if e.Usage != nil {
+		e.Usage()
+
+		return
+	}
+
+	if e.name == "" {
+		fmt.Fprintf(e.Output(), "Environment variables:\n")
+	} else {
+		fmt.Fprintf(e.Output(), "Environment variables of %s:\n", e.name)
+	}
+	e.PrintDefaults(e.Output())
+}
+
+func typeName(v Value) string {
+	if t, ok := v.(Typer); ok {
+		return t.Type()
+	}
+
+	return fmt.Sprintf("%T", v.Get())
+}
+
+// formatValue renders the value, values implementing fmt.Stringer render
+// themselves.
+func formatValue(v Value) string {
+	var s string
+	if stringer, ok := v.(fmt.Stringer); ok {
+		s = stringer.String()
+	} else {
+		s = fmt.Sprint(v.Get())
+	}
+
+	if s == "" {
+		return `""`
+	}
+
+	return s
+}
+
+// PrintDefaults writes the variables of the global set to w.
+func PrintDefaults(w io.Writer) {
+	environmentVariableSetInstance.PrintDefaults(w)
+}
+
+// Usage prints the usage of the global set.
+func Usage() {
+	environmentVariableSetInstance.PrintUsage()
+}
@@ -0,0 +1,323 @@
+package getenv
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var errRuleTypeMismatch = errors.New("type mismatch")
+
+// rule is a named check run against the value after Parse sets it.
+type rule struct {
+	check func(v any) error
+	name  string
+}
+
+func addRule(name string, check func(v any) error) Option {
+	return func(e *EnvironmentVariable) {
+		e.rules = append(e.rules, rule{name: name, check: check})
+	}
+}
+
+// checkRules runs the rules of the variable, the value is left out of the
+// error if the variable is sensitive.
+func (e *EnvironmentVariable) checkRules() error {
+	for _, r := range e.rules {
+		err := r.check(e.Value.Get())
+		if err == nil {
+			continue
+		}
+
+		if e.Sensitive && !errors.Is(err, errRuleTypeMismatch) {
+			return fmt.Errorf("[%w] rule %s: %s", ErrInvalid, r.name, redacted)
+		}
+
+		return fmt.Errorf("[%w] rule %s: %w", ErrInvalid, r.name, err)
+	}
+
+	return nil
+}
+
+// Min checks that the value is greater than or equal to minimum. It works
+// with Int, Int64, Float64, Duration, String and any ordered type; numeric
+// bounds are converted to the type of the value, Min(1) works for Int64 too,
+// a bound the type can not hold exactly, like Min(1.5) for Int, is a type
+// mismatch.
+func Min[T cmp.Ordered](minimum T) Option {
+	return addRule(fmt.Sprintf("min=%v", minimum), func(v any) error {
+		c, err := compareOrdered(v, minimum)
+		if err != nil {
+			return err
+		}
+		if c < 0 {
+			return fmt.Errorf("%v is less than %v", v, minimum)
+		}
+
+		return nil
+	})
+}
+
+// Max checks that the value is less than or equal to maximum, see Min.
+func Max[T cmp.Ordered](maximum T) Option {
+	return addRule(fmt.Sprintf("max=%v", maximum), func(v any) error {
+		c, err := compareOrdered(v, maximum)
+		if err != nil {
+			return err
+		}
+		if c > 0 {
+			return fmt.Errorf("%v is greater than %v", v, maximum)
+		}
+
+		return nil
+	})
+}
+
+// MinLength checks that a string value has at least n characters.
+func MinLength(n int) Option {
+	return addRule(fmt.Sprintf("minlength=%d", n), func(v any) error {
+		length, err := stringLength(v)
+		if err != nil {
+			return err
+		}
+		if length < n {
+			return fmt.Errorf("length %d is less than %d", length, n)
+		}
+
+		return nil
+	})
+}
+
+// MaxLength checks that a string value has at most n characters.
+func MaxLength(n int) Option {
+	return addRule(fmt.Sprintf("maxlength=%d", n), func(v any) error {
+		length, err := stringLength(v)
+		if err != nil {
+			return err
+		}
+		if length > n {
+			return fmt.Errorf("length %d is greater than %d", length, n)
+		}
+
+		return nil
+	})
+}
+
+// MinItems checks that a slice value, like StringSlice, has at least n
+// elements.
+func MinItems(n int) Option {
+	return addRule(fmt.Sprintf("minitems=%d", n), func(v any) error {
+		count, err := itemCount(v)
+		if err != nil {
+			return err
+		}
+		if count < n {
+			return fmt.Errorf("%d items are less than %d", count, n)
+		}
+
+		return nil
+	})
+}
+
+// MaxItems checks that a slice value has at most n elements.
+func MaxItems(n int) Option {
+	return addRule(fmt.Sprintf("maxitems=%d", n), func(v any) error {
+		count, err := itemCount(v)
+		if err != nil {
+			return err
+		}
+		if count > n {
+			return fmt.Errorf("%d items are greater than %d", count, n)
+		}
+
+		return nil
+	})
+}
+
+// Pattern checks that a string value matches the regular expression expr.
+// It panics if expr can not be compiled.
+func Pattern(expr string) Option {
+	re := regexp.MustCompile(expr)
+
+	return addRule("pattern="+expr, func(v any) error {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.String {
+			return fmt.Errorf("%w, %T is not a string", errRuleTypeMismatch, v)
+		}
+		if !re.MatchString(rv.String()) {
+			return fmt.Errorf("%q does not match", rv.String())
+		}
+
+		return nil
+	})
+}
+
+// OneOf checks that the value is one of values, numeric values are
+// converted to the type of the value like Min.
+func OneOf[T comparable](values ...T) Option {
+	allowed := make([]string, len(values))
+	for i, val := range values {
+		allowed[i] = fmt.Sprint(val)
+	}
+
+	return func(e *EnvironmentVariable) {
+		e.allowed = append(e.allowed, allowed...)
+		addRule("oneof="+strings.Join(allowed, "|"), func(v any) error {
+			for _, val := range values {
+				c, err := compareValues(v, val)
+				if err != nil {
+					return err
+				}
+				if c {
+					return nil
+				}
+			}
+
+			return fmt.Errorf("%v is not allowed", v)
+		})(e)
+	}
+}
+
+// Check adds a custom validation function, it is called with the value
+// after Parse sets it.
+func Check[T any](fn func(T) error) Option {
+	return addRule("check", func(v any) error {
+		typed, ok := v.(T)
+		if !ok {
+			return fmt.Errorf("%w, %T is not %s", errRuleTypeMismatch, v, reflect.TypeFor[T]())
+		}
+
+		return fn(typed)
+	})
+}
+
+// convertBound converts bound to the type of value if both are numbers or
+// strings, numeric bounds the type can not represent exactly are rejected.
+func convertBound(value, bound any) (reflect.Value, reflect.Value, error) {
+	rv, rb := reflect.ValueOf(value), reflect.ValueOf(bound)
+	if !rv.IsValid() || !rb.IsValid() {
+		return rv, rb, fmt.Errorf("%w, %T and %T", errRuleTypeMismatch, value, bound)
+	}
+
+	if rv.Type() == rb.Type() {
+		return rv, rb, nil
+	}
+
+	if isNumber(rv.Kind()) && isNumber(rb.Kind()) {
+		if !representable(rb, rv.Type()) {
+			return rv, rb, fmt.Errorf("%w, %v can not be represented as %T", errRuleTypeMismatch, bound, value)
+		}
+
+		return rv, rb.Convert(rv.Type()), nil
+	}
+
+	if rv.Kind() == reflect.String && rb.Kind() == reflect.String {
+		return rv, rb.Convert(rv.Type()), nil
+	}
+
+	return rv, rb, fmt.Errorf("%w, %T and %T", errRuleTypeMismatch, value, bound)
+}
+
+func compareOrdered(value, bound any) (int, error) {
+	rv, rb, err := convertBound(value, bound)
+	if err != nil {
+		return 0, err
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(rv.Int(), rb.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(rv.Uint(), rb.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(rv.Float(), rb.Float()), nil
+	case reflect.String:
+		return strings.Compare(rv.String(), rb.String()), nil
+	}
+
+	return 0, fmt.Errorf("%w, %T is not ordered", errRuleTypeMismatch, value)
+}
+
+func compareValues(value, other any) (bool, error) {
+	rv, ro, err := convertBound(value, other)
+	if err != nil {
+		return false, err
+	}
+
+	return rv.Equal(ro), nil
+}
+
+// representable reports whether the number n converts to t without
+// truncation, rounding, wrap around or overflow.
+func representable(n reflect.Value, t reflect.Type) bool {
+	target := reflect.Zero(t)
+
+	switch {
+	case isInt(t.Kind()):
+		switch {
+		case isInt(n.Kind()):
+			return !target.OverflowInt(n.Int())
+		case isUint(n.Kind()):
+			return n.Uint() <= math.MaxInt64 && !target.OverflowInt(int64(n.Uint()))
+		default:
+			f := n.Float()
+			return f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !target.OverflowInt(int64(f))
+		}
+	case isUint(t.Kind()):
+		switch {
+		case isInt(n.Kind()):
+			return n.Int() >= 0 && !target.OverflowUint(uint64(n.Int()))
+		case isUint(n.Kind()):
+			return !target.OverflowUint(n.Uint())
+		default:
+			f := n.Float()
+			return f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !target.OverflowUint(uint64(f))
+		}
+	}
+
+	switch {
+	case isInt(n.Kind()):
+		f := n.Convert(t).Float()
+		return f >= math.MinInt64 && f < math.MaxInt64 && int64(f) == n.Int()
+	case isUint(n.Kind()):
+		f := n.Convert(t).Float()
+		return f < math.MaxUint64 && uint64(f) == n.Uint()
+	default:
+		return !target.OverflowFloat(n.Float())
+	}
+}
+
+func isInt(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUint(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return (kind >= reflect.Int && kind <= reflect.Uintptr) || kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func stringLength(v any) (int, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.String {
+		return 0, fmt.Errorf("%w, %T is not a string", errRuleTypeMismatch, v)
+	}
+
+	return utf8.RuneCountInString(rv.String()), nil
+}
+
+func itemCount(v any) (int, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Map {
+		return 0, fmt.Errorf("%w, %T has no items", errRuleTypeMismatch, v)
+	}
+
+	return rv.Len(), nil
+}